@@ -9,6 +9,7 @@ import (
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -16,8 +17,11 @@ import (
 	"github.com/rs/cors"
 	httpSwagger "github.com/swaggo/http-swagger"
 
+	"github.com/maheshjq/web-analyzer_v1/internal/analyzer"
 	"github.com/maheshjq/web-analyzer_v1/internal/api"
+	"github.com/maheshjq/web-analyzer_v1/internal/fingerprint"
 	"github.com/maheshjq/web-analyzer_v1/internal/metrics"
+	"github.com/maheshjq/web-analyzer_v1/internal/secrets"
 
 	// Uncomment when you have generated swagger docs
 	_ "github.com/maheshjq/web-analyzer_v1/docs"
@@ -39,6 +43,59 @@ func init() {
 
 func main() {
 	api.EnableCaching = true
+
+	// Flag certificates expiring within this many days (default 30)
+	if days, err := strconv.Atoi(os.Getenv("CERT_EXPIRY_WINDOW_DAYS")); err == nil && days > 0 {
+		api.AnalyzerConfig.CertExpiryWindow = time.Duration(days) * 24 * time.Hour
+	}
+
+	// Download external scripts and stylesheets to verify their integrity hashes
+	if verify, err := strconv.ParseBool(os.Getenv("VERIFY_SRI")); err == nil {
+		api.AnalyzerConfig.VerifyIntegrity = verify
+	}
+
+	// Log in to the servers of ftp: links to check their target exists
+	if checkFTP, err := strconv.ParseBool(os.Getenv("CHECK_FTP")); err == nil {
+		api.AnalyzerConfig.CheckFTP = checkFTP
+	}
+
+	// Choose whether links to other hosts of the same site count as internal;
+	// a value that is set must name a policy, even when empty
+	if name, ok := os.LookupEnv("INTERNAL_LINK_POLICY"); ok {
+		policy, err := analyzer.ParseLinkPolicy(name)
+		if err != nil {
+			log.Fatalf("Invalid internal link policy: %v", err)
+		}
+		api.AnalyzerConfig.InternalLinks = policy
+	}
+
+	// Adjust the SEO audit weights and thresholds
+	if path := os.Getenv("SEO_CONFIG"); path != "" {
+		config, err := analyzer.LoadSEOConfig(path)
+		if err != nil {
+			log.Fatalf("Failed to load SEO config: %v", err)
+		}
+		api.AnalyzerConfig.SEO = config
+	}
+
+	// Override or extend the embedded technology fingerprinting rules
+	if path := os.Getenv("FINGERPRINT_RULES"); path != "" {
+		engine, err := fingerprint.LoadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to load fingerprint rules: %v", err)
+		}
+		api.AnalyzerConfig.Fingerprinter = engine
+	}
+
+	// Override or extend the embedded secret scanning rules
+	if path := os.Getenv("SECRET_RULES"); path != "" {
+		scanner, err := secrets.LoadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to load secret rules: %v", err)
+		}
+		api.AnalyzerConfig.SecretScanner = scanner
+	}
+
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
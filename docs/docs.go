@@ -32,7 +32,7 @@ const docTemplate = `{
                 "summary": "Analyze a web page",
                 "parameters": [
                     {
-                        "description": "URL to analyze",
+                        "description": "URL to analyze and an optional target keyword",
                         "name": "request",
                         "in": "body",
                         "required": true,
@@ -49,13 +49,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request (invalid URL format)",
+                        "description": "Invalid URL format or missing URL",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Failed to fetch or analyze the URL",
+                        "description": "Unable to fetch the URL or an error occurred during analysis",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -91,6 +91,11 @@ const docTemplate = `{
         "models.AnalysisRequest": {
             "type": "object",
             "properties": {
+                "targetKeyword": {
+                    "description": "TargetKeyword is an optional keyword or phrase to report on",
+                    "type": "string",
+                    "example": "tomato seeds"
+                },
                 "url": {
                     "type": "string",
                     "example": "https://example.com"
@@ -100,10 +105,28 @@ const docTemplate = `{
         "models.AnalysisResponse": {
             "type": "object",
             "properties": {
+                "canonical": {
+                    "$ref": "#/definitions/models.CanonicalReport"
+                },
+                "conformance": {
+                    "$ref": "#/definitions/models.ConformanceReport"
+                },
+                "consent": {
+                    "$ref": "#/definitions/models.ConsentReport"
+                },
                 "containsLoginForm": {
                     "type": "boolean",
                     "example": false
                 },
+                "content": {
+                    "$ref": "#/definitions/models.ContentReport"
+                },
+                "cookies": {
+                    "$ref": "#/definitions/models.CookieReport"
+                },
+                "doctype": {
+                    "$ref": "#/definitions/models.DoctypeInfo"
+                },
                 "headings": {
                     "$ref": "#/definitions/models.HeadingCount"
                 },
@@ -111,78 +134,1648 @@ const docTemplate = `{
                     "type": "string",
                     "example": "HTML5"
                 },
+                "integrity": {
+                    "$ref": "#/definitions/models.IntegrityReport"
+                },
+                "keywords": {
+                    "$ref": "#/definitions/models.KeywordReport"
+                },
                 "links": {
                     "$ref": "#/definitions/models.LinkAnalysis"
                 },
+                "loginForm": {
+                    "$ref": "#/definitions/models.LoginFormDetection"
+                },
+                "mixedContent": {
+                    "$ref": "#/definitions/models.MixedContentReport"
+                },
+                "mobile": {
+                    "$ref": "#/definitions/models.MobileReport"
+                },
+                "resources": {
+                    "$ref": "#/definitions/models.ResourceInventory"
+                },
+                "secrets": {
+                    "$ref": "#/definitions/models.SecretsReport"
+                },
+                "securityHeaders": {
+                    "$ref": "#/definitions/models.SecurityHeadersReport"
+                },
+                "seo": {
+                    "$ref": "#/definitions/models.SEOReport"
+                },
+                "sso": {
+                    "$ref": "#/definitions/models.SSODetection"
+                },
+                "technologies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Technology"
+                    }
+                },
                 "title": {
                     "type": "string",
                     "example": "Example Domain"
+                },
+                "tls": {
+                    "$ref": "#/definitions/models.TLSReport"
+                },
+                "trackers": {
+                    "$ref": "#/definitions/models.TrackerReport"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.AnchorText": {
             "type": "object",
             "properties": {
-                "message": {
+                "href": {
                     "type": "string",
-                    "example": "Failed to analyze URL: HTTP error 404 Not Found"
+                    "example": "/pricing"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Click here"
+                }
+            }
+        },
+        "models.CSPReport": {
+            "type": "object",
+            "properties": {
+                "directives": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "reportOnly": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "weaknesses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "'unsafe-inline' allowed in script-src"
+                    ]
+                }
+            }
+        },
+        "models.CanonicalCheck": {
+            "type": "object",
+            "properties": {
+                "crossDomain": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "error": {
+                    "type": "string"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "redirectsTo": {
+                    "type": "string",
+                    "example": "https://www.example.com/products/"
+                },
+                "selfReferencing": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "source": {
+                    "type": "string",
+                    "example": "html"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 502
+                    "example": 200
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.example.com/products"
                 }
             }
         },
-        "models.HeadingCount": {
+        "models.CanonicalReport": {
             "type": "object",
             "properties": {
-                "h1": {
+                "alternates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HreflangAlternate"
+                    }
+                },
+                "canonicals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CanonicalCheck"
+                    }
+                },
+                "hasXDefault": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CertificateInfo": {
+            "type": "object",
+            "properties": {
+                "dnsNames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "example.com",
+                        "www.example.com"
+                    ]
+                },
+                "issuer": {
+                    "type": "string",
+                    "example": "CN=R3,O=Let's Encrypt,C=US"
+                },
+                "notAfter": {
+                    "type": "string"
+                },
+                "notBefore": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "CN=example.com"
+                }
+            }
+        },
+        "models.ConformanceFinding": {
+            "type": "object",
+            "properties": {
+                "column": {
                     "type": "integer",
-                    "example": 1
+                    "example": 5
                 },
-                "h2": {
+                "element": {
+                    "type": "string",
+                    "example": "div"
+                },
+                "line": {
                     "type": "integer",
-                    "example": 2
+                    "example": 48
                 },
-                "h3": {
+                "message": {
+                    "type": "string",
+                    "example": "id \"main\" is already used at line 12"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "duplicate-id"
+                }
+            }
+        },
+        "models.ConformanceReport": {
+            "type": "object",
+            "properties": {
+                "byRule": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConformanceFinding"
+                    }
+                },
+                "total": {
                     "type": "integer",
                     "example": 3
                 },
-                "h4": {
+                "truncated": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.ConsentPlatform": {
+            "type": "object",
+            "properties": {
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "script:https://cdn.cookielaw.org/scripttemplates/otSDKStub.js"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "OneTrust"
+                }
+            }
+        },
+        "models.ConsentReport": {
+            "type": "object",
+            "properties": {
+                "bannerEvidence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "customBanner": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "detected": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "platforms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ConsentPlatform"
+                    }
+                },
+                "trackersWithoutConsent": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "unconsentedTrackers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ContentReport": {
+            "type": "object",
+            "properties": {
+                "declaredLanguage": {
+                    "type": "string",
+                    "example": "en-US"
+                },
+                "element": {
+                    "description": "Element is the element the main content was taken from",
+                    "type": "string",
+                    "example": "article"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "languageConfidence": {
+                    "type": "number",
+                    "example": 0.82
+                },
+                "readability": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReadabilityScore"
+                    }
+                },
+                "readingTimeMinutes": {
                     "type": "integer",
-                    "example": 0
+                    "example": 4
                 },
-                "h5": {
+                "sentenceCount": {
                     "type": "integer",
-                    "example": 0
+                    "example": 47
                 },
-                "h6": {
+                "text": {
+                    "type": "string"
+                },
+                "textToHtmlRatio": {
+                    "type": "number",
+                    "example": 18.25
+                },
+                "wordCount": {
                     "type": "integer",
-                    "example": 0
+                    "example": 812
                 }
             }
         },
-        "models.LinkAnalysis": {
+        "models.CookieFinding": {
             "type": "object",
             "properties": {
-                "external": {
+                "cookie": {
+                    "type": "string",
+                    "example": "sessionid"
+                },
+                "issue": {
+                    "type": "string",
+                    "example": "session cookie without HttpOnly"
+                }
+            }
+        },
+        "models.CookieInfo": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "type": "string",
+                    "example": "example.com"
+                },
+                "expires": {
+                    "type": "string"
+                },
+                "httpOnly": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "maxAge": {
                     "type": "integer",
-                    "example": 3
+                    "example": 3600
                 },
-                "inaccessible": {
+                "name": {
+                    "type": "string",
+                    "example": "sessionid"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/"
+                },
+                "sameSite": {
+                    "type": "string",
+                    "example": "Lax"
+                },
+                "secure": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "session": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "setBy": {
+                    "type": "string",
+                    "example": "example.com"
+                },
+                "thirdParty": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.CookieReport": {
+            "type": "object",
+            "properties": {
+                "cookies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CookieInfo"
+                    }
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CookieFinding"
+                    }
+                },
+                "thirdPartyDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tracker.example.net"
+                    ]
+                },
+                "total": {
                     "type": "integer",
-                    "example": 1
+                    "example": 3
+                }
+            }
+        },
+        "models.DanglingFragment": {
+            "type": "object",
+            "properties": {
+                "fragment": {
+                    "type": "string",
+                    "example": "install"
                 },
-                "internal": {
+                "href": {
+                    "type": "string",
+                    "example": "/docs#install"
+                },
+                "samePage": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/docs#install"
+                }
+            }
+        },
+        "models.DoctypeInfo": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string",
+                    "example": "text/html"
+                },
+                "known": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "html"
+                },
+                "present": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "publicId": {
+                    "type": "string",
+                    "example": "-//W3C//DTD HTML 4.01 Transitional//EN"
+                },
+                "renderingMode": {
+                    "type": "string",
+                    "example": "almost-standards"
+                },
+                "servedAsXhtml": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "systemId": {
+                    "type": "string",
+                    "example": "http://www.w3.org/TR/html4/loose.dtd"
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "Transitional"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "HTML 4.01"
+                }
+            }
+        },
+        "models.DuplicateLink": {
+            "type": "object",
+            "properties": {
+                "count": {
                     "type": "integer",
-                    "example": 5
+                    "example": 3
+                },
+                "texts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Pricing",
+                        "See plans"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/pricing"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Failed to analyze URL: HTTP error 404 Not Found"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 502
+                }
+            }
+        },
+        "models.HSTSReport": {
+            "type": "object",
+            "properties": {
+                "includeSubDomains": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "maxAge": {
+                    "type": "integer",
+                    "example": 31536000
+                },
+                "preload": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.HeaderCheck": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "X-Content-Type-Options"
+                },
+                "present": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pass"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "nosniff"
+                }
+            }
+        },
+        "models.HeadingCount": {
+            "type": "object",
+            "properties": {
+                "h1": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "h2": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "h3": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "h4": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "h5": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "h6": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.HreflangAlternate": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "hreflang": {
+                    "type": "string",
+                    "example": "de-CH"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reciprocal": {
+                    "description": "Reciprocal is set when the alternate was fetched and tells whether it\nlinks back to the page",
+                    "type": "boolean",
+                    "example": true
+                },
+                "source": {
+                    "type": "string",
+                    "example": "html"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.example.com/de-ch/products"
+                },
+                "validCode": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.IntegrityCheck": {
+            "type": "object",
+            "properties": {
+                "algorithms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "sha384"
+                    ]
+                },
+                "crossOrigin": {
+                    "type": "string",
+                    "example": "anonymous"
+                },
+                "crossOriginValid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "element": {
+                    "type": "string",
+                    "example": "script"
+                },
+                "hasIntegrity": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "host": {
+                    "type": "string",
+                    "example": "cdn.example.net"
+                },
+                "integrity": {
+                    "type": "string",
+                    "example": "sha384-oqVuAfXRKap7fdgcCY5uykM6+R9GqQ8K/uxy9rx7HNQlGYl1kPzQho1wx4JwY8wC"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thirdParty": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://cdn.example.net/lib.min.js"
+                },
+                "validFormat": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "verificationError": {
+                    "type": "string"
+                },
+                "verified": {
+                    "description": "Verified is set only when hash verification is enabled",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.IntegrityReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IntegrityCheck"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "unprotectedThirdParty": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://cdn.example.net/widget.js"
+                    ]
+                },
+                "withIntegrity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "withoutIntegrity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Keyword": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "density": {
+                    "type": "number",
+                    "example": 1.48
+                },
+                "inH1": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "inMetaDescription": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "inTitle": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "inUrl": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "term": {
+                    "type": "string",
+                    "example": "tomato"
+                }
+            }
+        },
+        "models.KeywordReport": {
+            "type": "object",
+            "properties": {
+                "bigrams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Keyword"
+                    }
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Keyword"
+                    }
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "target": {
+                    "$ref": "#/definitions/models.TargetKeywordReport"
+                },
+                "totalWords": {
+                    "type": "integer",
+                    "example": 812
+                },
+                "trigrams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Keyword"
+                    }
+                }
+            }
+        },
+        "models.LinkAnalysis": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "description": "Categories counts links by scheme category: web, email, phone, ftp,\ndata, javascript, file and app",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "danglingFragments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DanglingFragment"
+                    }
+                },
+                "external": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "fragmentLinks": {
+                    "description": "FragmentLinks counts the links whose fragment was checked against the\nanchors of their target page",
+                    "type": "integer",
+                    "example": 4
+                },
+                "inaccessible": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "internal": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "markup": {
+                    "$ref": "#/definitions/models.LinkMarkupReport"
+                },
+                "relations": {
+                    "description": "Relations counts web links by how their host relates to the page's:\nsame-host, same-site (same registrable domain) or cross-site",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "schemeLinks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SchemeLink"
+                    }
+                }
+            }
+        },
+        "models.LinkMarkupReport": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "duplicates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateLink"
+                    }
+                },
+                "emptyAnchors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "genericText": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AnchorText"
+                    }
+                },
+                "imagesWithoutAlt": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/"
+                    ]
+                },
+                "nofollow": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://partner.example.net/"
+                    ]
+                },
+                "sponsored": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ugc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unsafeTargetBlank": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://external.example.org/"
+                    ]
+                }
+            }
+        },
+        "models.LoginFormDetection": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number",
+                    "example": 0.9
+                },
+                "detected": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "password-input",
+                        "button-text"
+                    ]
+                }
+            }
+        },
+        "models.MixedContentItem": {
+            "type": "object",
+            "properties": {
+                "attribute": {
+                    "type": "string",
+                    "example": "src"
+                },
+                "blocked": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "category": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "element": {
+                    "type": "string",
+                    "example": "script"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://cdn.example.com/app.js"
+                }
+            }
+        },
+        "models.MixedContentReport": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "applicable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "insecureForms": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MixedContentItem"
+                    }
+                },
+                "passive": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.MobileReport": {
+            "type": "object",
+            "properties": {
+                "adjacentTapTargets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TapTargetPair"
+                    }
+                },
+                "appleTouchIcon": {
+                    "type": "string",
+                    "example": "/apple-touch-icon.png"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mobileFriendly": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "responsiveImages": {
+                    "$ref": "#/definitions/models.ResponsiveImageReport"
+                },
+                "themeColor": {
+                    "type": "string",
+                    "example": "#0a84ff"
+                },
+                "viewport": {
+                    "$ref": "#/definitions/models.ViewportReport"
+                },
+                "wideElements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WideElement"
+                    }
+                }
+            }
+        },
+        "models.ReadabilityScore": {
+            "type": "object",
+            "properties": {
+                "interpretation": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "flesch-reading-ease"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 64.2
+                }
+            }
+        },
+        "models.Resource": {
+            "type": "object",
+            "properties": {
+                "attribute": {
+                    "type": "string",
+                    "example": "src"
+                },
+                "available": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "element": {
+                    "type": "string",
+                    "example": "script"
+                },
+                "host": {
+                    "type": "string",
+                    "example": "cdn.example.com"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "thirdParty": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "type": "string",
+                    "example": "script"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://cdn.example.com/app.js"
+                }
+            }
+        },
+        "models.ResourceGroup": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer",
+                    "example": 120431
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "firstParty": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "inaccessible": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "thirdParty": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ResourceInventory": {
+            "type": "object",
+            "properties": {
+                "byType": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ResourceGroup"
+                    }
+                },
+                "firstParty": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "inaccessible": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "inaccessibleByType": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "pageWeight": {
+                    "type": "integer",
+                    "example": 512000
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Resource"
+                    }
+                },
+                "thirdParty": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "thirdPartyHosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fonts.googleapis.com"
+                    ]
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.ResponsiveImageReport": {
+            "type": "object",
+            "properties": {
+                "inPicture": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "srcsetWithoutSizes": {
+                    "description": "SrcsetWithoutSizes lists images with width descriptors but no sizes,\nwhich browsers treat as full viewport width",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/img/hero-800.jpg"
+                    ]
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "withSizes": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "withSrcset": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "models.SEOCheck": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "meta-description"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pass"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "Short description"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "models.SEOReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SEOCheck"
+                    }
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 82
+                }
+            }
+        },
+        "models.SSODetection": {
+            "type": "object",
+            "properties": {
+                "detected": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SSOProvider"
+                    }
+                }
+            }
+        },
+        "models.SSOProvider": {
+            "type": "object",
+            "properties": {
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "link:https://accounts.google.com/o/oauth2/v2/auth"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Google"
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "oidc"
+                }
+            }
+        },
+        "models.SchemeLink": {
+            "type": "object",
+            "properties": {
+                "accessible": {
+                    "description": "Accessible is set only for FTP links, when FTP checking is enabled",
+                    "type": "boolean",
+                    "example": true
+                },
+                "category": {
+                    "type": "string",
+                    "example": "phone"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "href": {
+                    "type": "string",
+                    "example": "tel:+44 20 7946 0958"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scheme": {
+                    "type": "string",
+                    "example": "tel"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.SecretFinding": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "description": {
+                    "type": "string",
+                    "example": "AWS access key ID"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "location": {
+                    "type": "string",
+                    "example": "script"
+                },
+                "match": {
+                    "type": "string",
+                    "example": "AKIA****************"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "aws-access-key-id"
+                },
+                "severity": {
+                    "type": "string",
+                    "example": "high"
+                }
+            }
+        },
+        "models.SecretsReport": {
+            "type": "object",
+            "properties": {
+                "bySeverity": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SecretFinding"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.SecurityHeadersReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HeaderCheck"
+                    }
+                },
+                "csp": {
+                    "$ref": "#/definitions/models.CSPReport"
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "hsts": {
+                    "$ref": "#/definitions/models.HSTSReport"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 75
+                }
+            }
+        },
+        "models.TLSReport": {
+            "type": "object",
+            "properties": {
+                "alpn": {
+                    "type": "string",
+                    "example": "h2"
+                },
+                "certificate": {
+                    "$ref": "#/definitions/models.CertificateInfo"
+                },
+                "chain": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CertificateInfo"
+                    }
+                },
+                "chainValid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "cipherSuite": {
+                    "type": "string",
+                    "example": "TLS_AES_128_GCM_SHA256"
+                },
+                "daysRemaining": {
+                    "type": "integer",
+                    "example": 62
+                },
+                "expiresSoon": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expiryWindowDays": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "hostnameMatch": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "verificationError": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "TLS 1.3"
+                }
+            }
+        },
+        "models.TapTargetPair": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string",
+                    "example": "a \"Terms\""
+                },
+                "second": {
+                    "type": "string",
+                    "example": "a \"Privacy\""
+                }
+            }
+        },
+        "models.TargetKeywordReport": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "density": {
+                    "type": "number",
+                    "example": 0.49
+                },
+                "keyword": {
+                    "type": "string",
+                    "example": "tomato seeds"
+                },
+                "missing": {
+                    "description": "Missing lists the prominent locations the keyword is absent from",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "meta-description"
+                    ]
+                },
+                "occurrences": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Technology": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "CMS"
+                },
+                "confidence": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "meta:generator"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "WordPress"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "6.4.2"
+                }
+            }
+        },
+        "models.Tracker": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "analytics"
+                },
+                "propertyIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "G-ABC123XYZ"
+                    ]
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "script:https://www.googletagmanager.com/gtag/js?id=G-ABC123XYZ"
+                    ]
+                },
+                "vendor": {
+                    "type": "string",
+                    "example": "Google Analytics"
+                }
+            }
+        },
+        "models.TrackerReport": {
+            "type": "object",
+            "properties": {
+                "byCategory": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "thirdPartyDomains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "www.googletagmanager.com"
+                    ]
+                },
+                "trackers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tracker"
+                    }
+                }
+            }
+        },
+        "models.ViewportReport": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "width=device-width, initial-scale=1"
+                },
+                "initialScale": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "present": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "width": {
+                    "type": "string",
+                    "example": "device-width"
+                },
+                "zoomDisabled": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.WideElement": {
+            "type": "object",
+            "properties": {
+                "element": {
+                    "type": "string",
+                    "example": "table"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "attribute"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 960
                 }
             }
         }
     }
 }`
 
-// SwaggerInfo holds exported Swagger Info
+// SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
@@ -1,32 +1,97 @@
 package analyzer
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/net/html"
 
+	"github.com/maheshjq/web-analyzer_v1/internal/fingerprint"
 	"github.com/maheshjq/web-analyzer_v1/internal/models"
+	"github.com/maheshjq/web-analyzer_v1/internal/secrets"
+	"github.com/maheshjq/web-analyzer_v1/internal/urlnorm"
 )
 
+// pageNormalizer identifies pages: links differing only in case, default
+// port, tracking parameters or fragment lead to the same page
+var pageNormalizer = urlnorm.Default()
+
+// linkNormalizer identifies link destinations, which include the fragment
+var linkNormalizer = &urlnorm.Normalizer{KeepFragment: true, TrackingParams: urlnorm.DefaultTrackingParams}
+
 type Analyzer struct {
 	client *http.Client
+	config Config
+}
+
+// Config switches on the optional parts of an analysis. The zero value keeps
+// the analysis to the page itself plus the link checks.
+type Config struct {
+	// CheckResources requests every subresource to record availability and size
+	CheckResources bool
+	// CertExpiryWindow flags certificates expiring within this duration;
+	// zero means defaultCertExpiryWindow
+	CertExpiryWindow time.Duration
+	// Fingerprinter detects technologies; nil means the embedded rules
+	Fingerprinter *fingerprint.Engine
+	// SecretScanner finds exposed secrets; nil means the embedded rules
+	SecretScanner *secrets.Scanner
+	// SEO holds the weights and thresholds of the SEO audit; zero settings
+	// take their DefaultSEOConfig value
+	SEO SEOConfig
+	// VerifyIntegrity downloads external scripts and stylesheets that carry an
+	// integrity attribute and checks their hash
+	VerifyIntegrity bool
+	// CheckFTP logs in to the servers of ftp: links to check their target exists
+	CheckFTP bool
+	// InternalLinks decides which links count as internal; empty means
+	// LinkPolicySameSite
+	InternalLinks LinkPolicy
+	// Readability maps language codes to the readability scorers of the
+	// main content; nil means DefaultReadabilityScorers
+	Readability map[string]ReadabilityScorer
+}
+
+// DefaultConfig returns the configuration used by NewAnalyzer
+func DefaultConfig() Config {
+	return Config{
+		CheckResources:   true,
+		CertExpiryWindow: defaultCertExpiryWindow,
+		SEO:              DefaultSEOConfig(),
+		InternalLinks:    LinkPolicySameSite,
+	}
 }
 
 func NewAnalyzer() *Analyzer {
+	return NewAnalyzerWithConfig(DefaultConfig())
+}
+
+// NewAnalyzerWithConfig creates an analyzer with the given configuration
+func NewAnalyzerWithConfig(config Config) *Analyzer {
 	return &Analyzer{
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		config: config,
 	}
 }
 
 // Analyze performs a full analysis of the webpage at the given URL
 func (a *Analyzer) Analyze(targetURL string) (*models.AnalysisResponse, error) {
+	return a.AnalyzeWithOptions(targetURL, models.AnalysisOptions{})
+}
+
+// AnalyzeWithOptions performs a full analysis of the webpage at the given URL
+// with the per-request options
+func (a *Analyzer) AnalyzeWithOptions(targetURL string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
 	// Fetch the page
 	resp, err := a.client.Get(targetURL)
 	if err != nil {
@@ -39,8 +104,14 @@ func (a *Analyzer) Analyze(targetURL string) (*models.AnalysisResponse, error) {
 		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
 	}
 
+	// Keep the raw body around for page weight and source-level checks
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
 	// Parse the HTML
-	doc, err := html.Parse(resp.Body)
+	doc, err := html.Parse(bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
@@ -50,6 +121,10 @@ func (a *Analyzer) Analyze(targetURL string) (*models.AnalysisResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base URL: %w", err)
 	}
+	// Resolve against the final URL so redirects, e.g. to HTTPS, are honored
+	if resp.Request != nil && resp.Request.URL != nil {
+		baseURL = resp.Request.URL
+	}
 
 	// Analyze the document
 	result := &models.AnalysisResponse{
@@ -57,52 +132,68 @@ func (a *Analyzer) Analyze(targetURL string) (*models.AnalysisResponse, error) {
 		Links:    models.LinkAnalysis{},
 	}
 
-	result.HTMLVersion = detectHTMLVersion(doc)
+	result.Doctype = analyzeDoctype(doc, resp.Header.Get("Content-Type"))
+	result.HTMLVersion = result.Doctype.Version
+
+	result.Conformance = checkConformance(body)
 
 	result.Title = extractTitle(doc)
 
 	countHeadings(doc, &result.Headings)
 
-	result.Links = analyzeLinks(doc, baseURL.Host, a.client)
+	result.Links = analyzeLinks(doc, baseURL, a.client, a.config.InternalLinks, a.config.CheckFTP)
+
+	result.SEO = auditSEO(doc, baseURL, resp.Header, result.Links.Internal, a.config.SEO)
+
+	result.Canonical = analyzeCanonical(doc, baseURL, resp.Header, a.client)
+
+	result.Content = analyzeContent(doc, body, a.config.Readability)
+
+	result.Keywords = analyzeKeywords(doc, baseURL, result.Content, options.TargetKeyword)
+
+	result.Mobile = analyzeMobile(doc)
+
+	result.LoginForm = detectLoginFormScored(doc)
+	result.ContainsLoginForm = result.LoginForm.Detected
+
+	result.SSO = detectSSO(doc, baseURL)
+
+	refs := resourceReferences(doc, baseURL)
+	resources := uniqueResources(refs)
+	if a.config.CheckResources {
+		checkResources(resources, a.client)
+	}
+	result.Resources = summarizeResources(resources, int64(len(body)))
+
+	result.MixedContent = detectMixedContent(doc, baseURL, refs)
+
+	result.SecurityHeaders = auditSecurityHeaders(resp.Header, baseURL.Scheme == "https")
+
+	if resp.TLS != nil {
+		result.TLS = inspectTLS(resp.TLS, baseURL.Hostname(), a.client, a.config.CertExpiryWindow)
+	}
+
+	result.Cookies = analyzeCookies(resp, baseURL)
 
-	result.ContainsLoginForm = detectLoginForm(doc)
+	result.Technologies = detectTechnologies(a.config.Fingerprinter, doc, resp.Header, body, resources, result.Cookies.Cookies)
+
+	result.Trackers = detectTrackers(doc, baseURL, resources)
+
+	result.Consent = detectConsent(doc, baseURL, result.Trackers)
+
+	result.Secrets = scanSecrets(a.config.SecretScanner, body)
+
+	result.Integrity = auditIntegrity(baseURL, refs)
+	if a.config.VerifyIntegrity {
+		verifyIntegrity(result.Integrity.Checks, a.client)
+	}
 
 	return result, nil
 }
 
+// detectHTMLVersion names the HTML version declared by the DOCTYPE
 func detectHTMLVersion(doc *html.Node) string {
-	if doc.Type == html.DocumentNode {
-		for child := doc.FirstChild; child != nil; child = child.NextSibling {
-			if child.Type == html.DoctypeNode {
-				// HTML5: <!DOCTYPE html> with no attributes
-				if strings.ToLower(child.Data) == "html" && len(child.Attr) == 0 {
-					return "HTML5"
-				}
-				// Check for older versions
-				for _, attr := range child.Attr {
-					if attr.Key == "public" {
-						pubID := strings.ToLower(attr.Val)
-						if strings.Contains(pubID, "html 4") {
-							return "HTML 4.01"
-						} else if strings.Contains(pubID, "xhtml 1.0") {
-							return "XHTML 1.0"
-						} else if strings.Contains(pubID, "xhtml 1.1") {
-							return "XHTML 1.1"
-						}
-					}
-				}
-				return "Unknown DOCTYPE"
-			}
-		}
-	}
-	// Check for HTML5 elements if no doctype
-	html5Elements := []string{"article", "aside", "audio", "canvas", "footer", "header", "nav", "section", "video"}
-	for _, element := range html5Elements {
-		if findElement(doc, element) {
-			return "HTML5 (No DOCTYPE)"
-		}
-	}
-	return "Unknown (No DOCTYPE)"
+	return analyzeDoctype(doc, "").Version
 }
 
 // findElement searches for a specific element in the document
@@ -120,6 +211,62 @@ func findElement(n *html.Node, tagName string) bool {
 	return false
 }
 
+// sourcePosition tracks the line and column reached while tokenizing a body
+type sourcePosition struct {
+	line   int
+	column int
+}
+
+func newSourcePosition() *sourcePosition {
+	return &sourcePosition{line: 1, column: 1}
+}
+
+// advance moves the position past raw, counting columns in characters
+func (p *sourcePosition) advance(raw string) {
+	if newlines := strings.Count(raw, "\n"); newlines > 0 {
+		p.line += newlines
+		p.column = 1 + utf8.RuneCountInString(raw[strings.LastIndex(raw, "\n")+1:])
+	} else {
+		p.column += utf8.RuneCountInString(raw)
+	}
+}
+
+// getAttrOK returns an attribute value and whether the attribute is present
+func getAttrOK(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// getAttr returns the value of the named attribute, or "" when it is absent
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// textContent returns the concatenated text of a node and its descendants
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
 func extractTitle(doc *html.Node) string {
 	var title string
 	var findTitle func(*html.Node) bool
@@ -185,14 +332,16 @@ func countHeadings(doc *html.Node, headings *models.HeadingCount) {
 	crawler(doc)
 }
 
-func analyzeLinks(doc *html.Node, host string, client *http.Client) models.LinkAnalysis {
+func analyzeLinks(doc *html.Node, baseURL *url.URL, client *http.Client, policy LinkPolicy, checkFTP bool) models.LinkAnalysis {
 	var links []string
+	markup := newLinkMarkup(baseURL)
 	var extractLinks func(*html.Node)
 	extractLinks = func(n *html.Node) {
 		if n.Type == html.ElementNode && n.Data == "a" {
 			for _, attr := range n.Attr {
 				if attr.Key == "href" {
 					links = append(links, attr.Val)
+					markup.add(n, attr.Val)
 					break
 				}
 			}
@@ -203,17 +352,29 @@ func analyzeLinks(doc *html.Node, host string, client *http.Client) models.LinkA
 	}
 	extractLinks(doc)
 
+	anchors := collectAnchors(doc)
+	pages := newAnchorCache(client)
+	checks := newLinkCheckCache(client)
+
 	// Define a result struct for channel communication
 	type linkResult struct {
 		isInternal     bool
 		isExternal     bool
 		isInaccessible bool
+		fragmentLink   bool
+		dangling       *models.DanglingFragment
+		schemeLink     *models.SchemeLink
+		relation       string
 	}
 
 	resultCh := make(chan linkResult, len(links))
 	var wg sync.WaitGroup
 
+	categories := make(map[string]int)
 	for _, link := range links {
+		if link != "" {
+			categories[linkCategory(link)]++
+		}
 		if link == "" || strings.HasPrefix(link, "javascript:") {
 			continue // Skip empty or js links
 		}
@@ -222,16 +383,53 @@ func analyzeLinks(doc *html.Node, host string, client *http.Client) models.LinkA
 		go func(l string) {
 			defer wg.Done()
 
+			relation := linkRelation(l, baseURL)
 			result := linkResult{
-				isInternal:     isInternalLink(l, host),
-				isExternal:     !isInternalLink(l, host),
 				isInaccessible: false,
+				relation:       relation,
 			}
 
-			if strings.HasPrefix(l, "http") {
-				fmt.Println("Checking accessibility for:", l) // Debug print
-				if !isAccessibleLink(l, client) {
-					fmt.Println("Inaccessible link found:", l) // Debug print
+			if category := linkCategory(l); category != linkCategoryWeb {
+				result.schemeLink = inspectSchemeLink(l, category, checkFTP, client.Timeout)
+				if result.schemeLink.Accessible != nil && !*result.schemeLink.Accessible {
+					result.isInaccessible = true
+				}
+				resultCh <- result
+				return
+			}
+
+			// Only web links are internal or external
+			result.isInternal = policy.isInternal(relation)
+			result.isExternal = !result.isInternal
+
+			u, err := baseURL.Parse(strings.TrimSpace(l))
+			switch {
+			case err == nil && (u.Scheme == "http" || u.Scheme == "https") && isCheckableFragment(u.Fragment):
+				// Fragment links are checked against the anchors of their page
+				result.fragmentLink = true
+				samePage := comparableURL(u) == comparableURL(baseURL)
+				found := true
+				if samePage {
+					found = hasAnchor(anchors, u)
+				} else {
+					page := pages.page(u)
+					if page.err != nil || !isAccessibleStatus(page.statusCode) {
+						result.isInaccessible = true
+					} else if page.anchors != nil {
+						found = hasAnchor(page.anchors, u)
+					}
+				}
+				if !found {
+					result.isInaccessible = true
+					result.dangling = &models.DanglingFragment{
+						Href:     l,
+						URL:      u.String(),
+						Fragment: u.Fragment,
+						SamePage: samePage,
+					}
+				}
+			case strings.HasPrefix(l, "http"):
+				if !checks.accessible(l) {
 					result.isInaccessible = true
 				}
 			}
@@ -247,38 +445,52 @@ func analyzeLinks(doc *html.Node, host string, client *http.Client) models.LinkA
 	}()
 
 	// Count the results
-	var internal, external, inaccessible int
+	analysis := models.LinkAnalysis{
+		DanglingFragments: []models.DanglingFragment{},
+		Markup:            markup.finish(),
+		Categories:        categories,
+		SchemeLinks:       []models.SchemeLink{},
+		Relations:         make(map[string]int),
+	}
 	for result := range resultCh {
 		if result.isInternal {
-			internal++
+			analysis.Internal++
 		}
 		if result.isExternal {
-			external++
+			analysis.External++
 		}
 		if result.isInaccessible {
-			inaccessible++
+			analysis.Inaccessible++
+		}
+		if result.relation != "" {
+			analysis.Relations[result.relation]++
+		}
+		if result.fragmentLink {
+			analysis.FragmentLinks++
+		}
+		if result.dangling != nil {
+			analysis.DanglingFragments = append(analysis.DanglingFragments, *result.dangling)
+		}
+		if result.schemeLink != nil {
+			analysis.SchemeLinks = append(analysis.SchemeLinks, *result.schemeLink)
 		}
 	}
 
-	return models.LinkAnalysis{
-		Internal:     internal,
-		External:     external,
-		Inaccessible: inaccessible,
-	}
+	sort.Slice(analysis.DanglingFragments, func(i, j int) bool {
+		return analysis.DanglingFragments[i].URL < analysis.DanglingFragments[j].URL
+	})
+	sort.Slice(analysis.SchemeLinks, func(i, j int) bool {
+		return analysis.SchemeLinks[i].Href < analysis.SchemeLinks[j].Href
+	})
+
+	return analysis
 }
 
+// isInternalLink reports whether href points to the page's own host. The
+// scheme of the page is not known, so a host without a port matches the
+// default port of the link.
 func isInternalLink(href, host string) bool {
-	if href == "" || strings.HasPrefix(href, "#") {
-		return true
-	}
-	if strings.HasPrefix(href, "/") || strings.HasPrefix(href, "./") || strings.HasPrefix(href, "../") {
-		return true
-	}
-	u, err := url.Parse(href)
-	if err != nil || (u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https") {
-		return false
-	}
-	return u.Host == host || u.Host == ""
+	return LinkPolicySameHost.isInternal(linkRelation(href, &url.URL{Host: host}))
 }
 
 func isAccessibleLink(link string, client *http.Client) bool {
@@ -287,75 +499,53 @@ func isAccessibleLink(link string, client *http.Client) bool {
 		return true
 	}
 
-	resp, err := client.Head(link)
+	status, _, err := checkURL(link, client)
 	if err != nil {
 		return false
 	}
-	defer resp.Body.Close()
 
-	// 2xx and 3xx status codes are considered accessible
-	return resp.StatusCode >= 200 && resp.StatusCode < 400
+	return isAccessibleStatus(status)
 }
 
-// detectLoginForm detects if the document contains a login form
-func detectLoginForm(doc *html.Node) bool {
-	var hasLoginForm bool
-
-	var crawler func(*html.Node)
-	crawler = func(n *html.Node) {
-		if hasLoginForm {
-			return
-		}
-
-		if n.Type == html.ElementNode && n.Data == "form" {
-			// Check for form attributes that suggest a login form
-			var formAction, formId, formClass string
-			for _, attr := range n.Attr {
-				switch attr.Key {
-				case "action":
-					formAction = strings.ToLower(attr.Val)
-				case "id":
-					formId = strings.ToLower(attr.Val)
-				case "class":
-					formClass = strings.ToLower(attr.Val)
-				}
-			}
-
-			if strings.Contains(formAction, "login") ||
-				strings.Contains(formAction, "signin") ||
-				strings.Contains(formId, "login") ||
-				strings.Contains(formId, "signin") ||
-				strings.Contains(formClass, "login") ||
-				strings.Contains(formClass, "signin") {
-				hasLoginForm = true
-				return
-			}
-
-			// Check form elements for password inputs
-			var formCrawler func(*html.Node)
-			formCrawler = func(node *html.Node) {
-				if node.Type == html.ElementNode && node.Data == "input" {
-					for _, attr := range node.Attr {
-						if attr.Key == "type" && attr.Val == "password" {
-							hasLoginForm = true
-							return
-						}
-					}
-				}
+// linkCheckCache checks each distinct destination once, however many links
+// on the page point to it
+type linkCheckCache struct {
+	client  *http.Client
+	mu      sync.Mutex
+	results map[string]*linkCheck
+}
 
-				for c := node.FirstChild; c != nil; c = c.NextSibling {
-					formCrawler(c)
-				}
-			}
+type linkCheck struct {
+	once       sync.Once
+	accessible bool
+}
 
-			formCrawler(n)
-		}
+func newLinkCheckCache(client *http.Client) *linkCheckCache {
+	return &linkCheckCache{client: client, results: make(map[string]*linkCheck)}
+}
 
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			crawler(c)
-		}
+// accessible reports whether link is accessible, checking it only the first
+// time its normalized form is seen
+func (c *linkCheckCache) accessible(link string) bool {
+	key := link
+	if u, err := url.Parse(strings.TrimSpace(link)); err == nil {
+		key = comparableURL(u)
+	}
+	c.mu.Lock()
+	check, ok := c.results[key]
+	if !ok {
+		check = &linkCheck{}
+		c.results[key] = check
 	}
+	c.mu.Unlock()
 
-	crawler(doc)
-	return hasLoginForm
+	check.once.Do(func() {
+		check.accessible = isAccessibleLink(link, c.client)
+	})
+	return check.accessible
+}
+
+// isAccessibleStatus treats 2xx and 3xx status codes as accessible
+func isAccessibleStatus(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 400
 }
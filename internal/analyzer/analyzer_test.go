@@ -508,6 +508,7 @@ func TestAnalyzeLinks(t *testing.T) {
 			<a href="mailto:test@example.com">Email</a>
 			<a href="#section">Section</a>
 			<a href="javascript:void(0)">JS Link</a>
+			<h2 id="section">Section</h2>
 		</body></html>
 	`
 
@@ -526,11 +527,12 @@ func TestAnalyzeLinks(t *testing.T) {
 	}
 
 	// Analyze links
-	result := analyzeLinks(doc, "example.com", client)
+	baseURL, _ := url.Parse("https://example.com/")
+	result := analyzeLinks(doc, baseURL, client, LinkPolicySameSite, false)
 
 	// Check the results
 	assert.GreaterOrEqual(t, result.Internal, 3) // Home, About, Section should be internal
-	assert.GreaterOrEqual(t, result.External, 2) // External and Email should be external
+	assert.Equal(t, 1, result.External)          // Email links are neither internal nor external
 	assert.Equal(t, 0, result.Inaccessible)      // All links are accessible in our mock
 }
 
@@ -0,0 +1,44 @@
+package analyzer
+
+import "github.com/maheshjq/web-analyzer_v1/internal/models"
+
+// Verdicts for a single graded check
+const (
+	statusPass = "pass"
+	statusWarn = "warn"
+	statusFail = "fail"
+)
+
+// passingCheck is where every graded check starts
+func passingCheck() models.CheckResult {
+	return models.CheckResult{Status: statusPass, Issues: []string{}}
+}
+
+// warnCheck records an issue and downgrades a passing check to a warning
+func warnCheck(check *models.CheckResult, issue string) {
+	check.Issues = append(check.Issues, issue)
+	if check.Status == statusPass {
+		check.Status = statusWarn
+	}
+}
+
+// failCheck records an issue and fails the check
+func failCheck(check *models.CheckResult, issue string) {
+	check.Issues = append(check.Issues, issue)
+	check.Status = statusFail
+}
+
+// letterGrade turns a 0-100 score into a grade from A to F
+func letterGrade(score int) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 75:
+		return "B"
+	case score >= 60:
+		return "C"
+	case score >= 40:
+		return "D"
+	}
+	return "F"
+}
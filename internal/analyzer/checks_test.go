@@ -0,0 +1,26 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheckVerdicts tests that warnings never upgrade a failed check
+func TestCheckVerdicts(t *testing.T) {
+	check := passingCheck()
+	warnCheck(&check, "first")
+	assert.Equal(t, statusWarn, check.Status)
+	failCheck(&check, "second")
+	warnCheck(&check, "third")
+	assert.Equal(t, statusFail, check.Status)
+	assert.Equal(t, []string{"first", "second", "third"}, check.Issues)
+}
+
+// TestLetterGrade tests the score boundaries of each grade
+func TestLetterGrade(t *testing.T) {
+	grades := map[int]string{100: "A", 90: "A", 89: "B", 75: "B", 74: "C", 60: "C", 59: "D", 40: "D", 39: "F", 0: "F"}
+	for score, want := range grades {
+		assert.Equal(t, want, letterGrade(score), score)
+	}
+}
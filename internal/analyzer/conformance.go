@@ -0,0 +1,268 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// maxConformanceFindings caps the findings listed; the counts stay exact
+const maxConformanceFindings = 200
+
+// Conformance rules
+const (
+	ruleObsoleteElement    = "obsolete-element"
+	ruleDuplicateID        = "duplicate-id"
+	ruleIllegalNesting     = "illegal-nesting"
+	ruleMisnestedTag       = "misnested-tag"
+	ruleUnclosedTag        = "unclosed-tag"
+	ruleStrayEndTag        = "stray-end-tag"
+	ruleSelfClosingNonVoid = "self-closing-non-void"
+	ruleInlineEventHandler = "inline-event-handler"
+)
+
+// obsoleteElements are the elements the HTML spec lists as obsolete
+var obsoleteElements = toSet(
+	"acronym", "applet", "basefont", "bgsound", "big", "blink", "center", "dir",
+	"font", "frame", "frameset", "isindex", "keygen", "listing", "marquee",
+	"menuitem", "multicol", "nextid", "nobr", "noembed", "noframes", "plaintext",
+	"rb", "rtc", "spacer", "strike", "tt", "xmp",
+)
+
+// voidElements never have content or an end tag
+var voidElements = toSet(
+	"area", "base", "br", "col", "embed", "hr", "img", "input", "keygen",
+	"link", "meta", "param", "source", "track", "wbr",
+)
+
+// optionalEndTags are elements whose end tag may be omitted
+var optionalEndTags = toSet(
+	"html", "head", "body", "p", "li", "dt", "dd", "option", "optgroup",
+	"tr", "td", "th", "thead", "tbody", "tfoot", "colgroup", "caption",
+	"rb", "rt", "rtc", "rp",
+)
+
+// blockElements may not appear inside phrasing-only elements
+var blockElements = toSet(
+	"address", "article", "aside", "blockquote", "details", "dialog", "div",
+	"dl", "fieldset", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5",
+	"h6", "header", "hr", "main", "nav", "ol", "p", "pre", "section", "table", "ul",
+)
+
+// phrasingElements only accept phrasing content
+var phrasingElements = toSet(
+	"abbr", "b", "bdi", "bdo", "big", "button", "cite", "code", "data", "dfn",
+	"em", "font", "i", "kbd", "label", "mark", "q", "s", "samp", "small", "span",
+	"strike", "strong", "sub", "sup", "time", "tt", "u", "var",
+)
+
+// interactiveElements may not be nested in a button or a link
+var interactiveElements = toSet(
+	"a", "button", "details", "embed", "iframe", "input", "label", "select", "textarea",
+)
+
+// implicitlyClosedBy lists, for a start tag, the open elements it closes
+// without an end tag, following the parser's optional end tag rules
+var implicitlyClosedBy = map[string][]string{
+	"li":       {"li"},
+	"dt":       {"dt", "dd"},
+	"dd":       {"dt", "dd"},
+	"option":   {"option"},
+	"optgroup": {"option", "optgroup"},
+	"tr":       {"td", "th", "tr"},
+	"td":       {"td", "th"},
+	"th":       {"td", "th"},
+	"thead":    {"td", "th", "tr", "tbody", "thead", "tfoot", "colgroup", "caption"},
+	"tbody":    {"td", "th", "tr", "tbody", "thead", "tfoot", "colgroup", "caption"},
+	"tfoot":    {"td", "th", "tr", "tbody", "thead", "tfoot", "colgroup", "caption"},
+	"body":     {"head"},
+	"rt":       {"rb", "rt", "rp"},
+	"rp":       {"rb", "rt", "rp"},
+}
+
+type openElement struct {
+	name   string
+	line   int
+	column int
+}
+
+// checkConformance tokenizes the raw body and reports the markup problems
+// the parser silently recovers from, with their line and column
+func checkConformance(body []byte) models.ConformanceReport {
+	report := models.ConformanceReport{
+		ByRule:   make(map[string]int),
+		Findings: []models.ConformanceFinding{},
+	}
+
+	add := func(rule, element string, line, column int, format string, args ...any) {
+		report.Total++
+		report.ByRule[rule]++
+		report.Findings = append(report.Findings, models.ConformanceFinding{
+			Rule:    rule,
+			Message: fmt.Sprintf(format, args...),
+			Element: element,
+			Line:    line,
+			Column:  column,
+		})
+	}
+
+	var stack []openElement
+	ids := make(map[string]openElement)
+	z := html.NewTokenizer(bytes.NewReader(body))
+	pos := newSourcePosition()
+
+	has := func(name string) bool {
+		for _, e := range stack {
+			if e.name == name {
+				return true
+			}
+		}
+		return false
+	}
+	inForeignContent := func() bool {
+		return has("svg") || has("math")
+	}
+
+	for {
+		tokenType := z.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		raw := string(z.Raw())
+		line, column := pos.line, pos.column
+		pos.advance(raw)
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			nameBytes, hasAttr := z.TagName()
+			name := string(nameBytes)
+			element := openElement{name, line, column}
+
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				switch attr := string(key); {
+				case attr == "id" && len(val) > 0:
+					id := string(val)
+					if first, ok := ids[id]; ok {
+						add(ruleDuplicateID, name, line, column, "id %q is already used by <%s> at line %d", id, first.name, first.line)
+					} else {
+						ids[id] = element
+					}
+				case strings.HasPrefix(attr, "on") && len(attr) > 2:
+					add(ruleInlineEventHandler, name, line, column, "inline event handler %s on <%s>", attr, name)
+				}
+			}
+
+			if obsoleteElements[name] {
+				add(ruleObsoleteElement, name, line, column, "<%s> is obsolete", name)
+			}
+
+			for _, closable := range implicitlyClosedBy[name] {
+				for len(stack) > 0 && stack[len(stack)-1].name == closable {
+					stack = stack[:len(stack)-1]
+				}
+			}
+			if blockElements[name] && len(stack) > 0 && stack[len(stack)-1].name == "p" {
+				stack = stack[:len(stack)-1]
+			}
+
+			switch {
+			case name == "form" && has("form"):
+				add(ruleIllegalNesting, name, line, column, "<form> nested in another <form>")
+			case name == "a" && has("a"):
+				add(ruleIllegalNesting, name, line, column, "<a> nested in another <a>")
+			case interactiveElements[name] && has("button"):
+				add(ruleIllegalNesting, name, line, column, "interactive <%s> nested in <button>", name)
+			case blockElements[name]:
+				// The nearest ancestor that decides the content model; links
+				// are transparent
+				for i := len(stack) - 1; i >= 0; i-- {
+					parent := stack[i].name
+					if blockElements[parent] {
+						break
+					}
+					if phrasingElements[parent] {
+						add(ruleIllegalNesting, name, line, column, "block <%s> nested in inline <%s>", name, parent)
+						break
+					}
+				}
+			}
+
+			if voidElements[name] {
+				continue
+			}
+			if tokenType == html.SelfClosingTagToken {
+				if inForeignContent() {
+					continue
+				}
+				add(ruleSelfClosingNonVoid, name, line, column, "<%s/> is not self-closing in HTML; the element stays open", name)
+			}
+			stack = append(stack, element)
+
+		case html.EndTagToken:
+			nameBytes, _ := z.TagName()
+			name := string(nameBytes)
+			if voidElements[name] {
+				continue
+			}
+
+			match := -1
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name == name {
+					match = i
+					break
+				}
+			}
+			if match < 0 {
+				if !optionalEndTags[name] {
+					add(ruleStrayEndTag, name, line, column, "</%s> has no matching start tag", name)
+				}
+				continue
+			}
+			for _, open := range stack[match+1:] {
+				if optionalEndTags[open.name] {
+					continue
+				}
+				// Closing the document ends whatever is left open
+				if name == "body" || name == "html" {
+					add(ruleUnclosedTag, open.name, open.line, open.column, "<%s> is never closed", open.name)
+				} else {
+					add(ruleMisnestedTag, open.name, line, column, "</%s> closes <%s> opened at line %d, column %d", name, open.name, open.line, open.column)
+				}
+			}
+			stack = stack[:match]
+		}
+	}
+
+	for _, open := range stack {
+		if !optionalEndTags[open.name] {
+			add(ruleUnclosedTag, open.name, open.line, open.column, "<%s> is never closed", open.name)
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	// The earliest findings are kept, whichever pass found them
+	if len(report.Findings) > maxConformanceFindings {
+		report.Findings = report.Findings[:maxConformanceFindings]
+		report.Truncated = true
+	}
+
+	return report
+}
+
+func toSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+func findingsByRule(report models.ConformanceReport) map[string][]models.ConformanceFinding {
+	found := make(map[string][]models.ConformanceFinding)
+	for _, f := range report.Findings {
+		found[f.Rule] = append(found[f.Rule], f)
+	}
+	return found
+}
+
+// TestCheckConformance tests each rule and the reported positions
+func TestCheckConformance(t *testing.T) {
+	body := `<!DOCTYPE html>
+<html><head><title>Test</title></head>
+<body onload="init()">
+<center><font color="red">Old</font></center>
+<div id="main"></div>
+<section id="main"></section>
+<span><div>block in inline</div></span>
+<form><form></form></form>
+<a href="/a"><a href="/b">nested</a></a>
+<button><a href="/c">link</a></button>
+<p><b><i>misnested</b></i></p>
+<div/>
+</section>
+<svg><path d="M0 0"/></svg>
+<ul><li>one<li>two</ul>
+<table><tr><td>1<td>2</table>
+<p>one<p>two<div>three</div>
+<br></br>
+<main>
+</body></html>`
+
+	report := checkConformance([]byte(body))
+	found := findingsByRule(report)
+
+	require.Len(t, found[ruleInlineEventHandler], 1)
+	assert.Equal(t, "body", found[ruleInlineEventHandler][0].Element)
+	assert.Equal(t, 3, found[ruleInlineEventHandler][0].Line)
+	assert.Equal(t, 1, found[ruleInlineEventHandler][0].Column)
+
+	require.Len(t, found[ruleObsoleteElement], 2)
+	assert.Equal(t, "center", found[ruleObsoleteElement][0].Element)
+	assert.Equal(t, "font", found[ruleObsoleteElement][1].Element)
+	assert.Equal(t, 4, found[ruleObsoleteElement][1].Line)
+	assert.Equal(t, 9, found[ruleObsoleteElement][1].Column)
+
+	require.Len(t, found[ruleDuplicateID], 1)
+	assert.Equal(t, "section", found[ruleDuplicateID][0].Element)
+	assert.Equal(t, 6, found[ruleDuplicateID][0].Line)
+	assert.Contains(t, found[ruleDuplicateID][0].Message, "line 5")
+
+	var nesting []string
+	for _, f := range found[ruleIllegalNesting] {
+		nesting = append(nesting, fmt.Sprintf("%d:%s", f.Line, f.Element))
+	}
+	assert.Equal(t, []string{"7:div", "8:form", "9:a", "10:a"}, nesting)
+
+	require.Len(t, found[ruleMisnestedTag], 1)
+	assert.Equal(t, "i", found[ruleMisnestedTag][0].Element)
+	assert.Equal(t, 11, found[ruleMisnestedTag][0].Line)
+
+	require.Len(t, found[ruleSelfClosingNonVoid], 1)
+	assert.Equal(t, 12, found[ruleSelfClosingNonVoid][0].Line)
+
+	var stray []string
+	for _, f := range found[ruleStrayEndTag] {
+		stray = append(stray, fmt.Sprintf("%d:%s", f.Line, f.Element))
+	}
+	assert.Equal(t, []string{"11:i", "13:section"}, stray)
+
+	var unclosed []string
+	for _, f := range found[ruleUnclosedTag] {
+		unclosed = append(unclosed, fmt.Sprintf("%d:%s", f.Line, f.Element))
+	}
+	assert.Equal(t, []string{"12:div", "19:main"}, unclosed)
+
+	assert.Equal(t, len(report.Findings), report.Total)
+	assert.False(t, report.Truncated)
+}
+
+// TestCheckConformanceClean tests that well-formed markup with omitted
+// optional end tags has no findings
+func TestCheckConformanceClean(t *testing.T) {
+	body := `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Clean</title>
+<body>
+<p>First<p>Second
+<ul><li>One<li>Two</ul>
+<dl><dt>Term<dd>Definition</dl>
+<table><thead><tr><th>A<tbody><tr><td>1</table>
+<a href="/"><div>Block links are allowed</div></a>
+<img src="a.png"><br/><input type="text">
+<svg viewBox="0 0 1 1"><circle r="1"/></svg>
+`
+
+	report := checkConformance([]byte(body))
+
+	assert.Empty(t, report.Findings)
+	assert.Equal(t, 0, report.Total)
+}
+
+// TestCheckConformanceTruncated tests that findings beyond the limit are
+// counted but not listed
+func TestCheckConformanceTruncated(t *testing.T) {
+	body := strings.Repeat("<font>x</font>\n", maxConformanceFindings+10)
+
+	report := checkConformance([]byte(body))
+
+	assert.True(t, report.Truncated)
+	assert.Len(t, report.Findings, maxConformanceFindings)
+	assert.Equal(t, maxConformanceFindings+10, report.Total)
+	assert.Equal(t, maxConformanceFindings+10, report.ByRule[ruleObsoleteElement])
+}
+
+// TestCheckConformanceTruncatedKeepsEarliest tests that the end-of-document
+// pass does not push out findings from earlier lines
+func TestCheckConformanceTruncatedKeepsEarliest(t *testing.T) {
+	body := "<div>\n" + strings.Repeat("<font>x</font>\n", maxConformanceFindings+10)
+
+	report := checkConformance([]byte(body))
+
+	require.Len(t, report.Findings, maxConformanceFindings)
+	assert.Equal(t, ruleUnclosedTag, report.Findings[0].Rule)
+	assert.Equal(t, 1, report.Findings[0].Line)
+	assert.Equal(t, maxConformanceFindings, report.Findings[len(report.Findings)-1].Line)
+}
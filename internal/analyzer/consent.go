@@ -0,0 +1,240 @@
+package analyzer
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// consentPlatform describes how a consent management platform shows up in markup
+type consentPlatform struct {
+	name string
+	// endpoints are the URLs the platform loads from, see matchesEndpoint
+	endpoints []string
+	// markers are lowercase substrings of the ids and classes the platform
+	// renders, or of its inline loader
+	markers []string
+}
+
+var consentPlatforms = []consentPlatform{
+	{"OneTrust", []string{"cdn.cookielaw.org", "optanon.blob.core.windows.net", "cookie-cdn.cookiepro.com", "onetrust.com"}, []string{"onetrust-", "optanon"}},
+	{"Cookiebot", []string{"consent.cookiebot.com", "consentcdn.cookiebot.com"}, []string{"cybotcookiebot", "cookiebot"}},
+	{"Usercentrics", []string{"usercentrics.eu", "usercentrics.com"}, []string{"usercentrics"}},
+	{"Didomi", []string{"sdk.privacy-center.org"}, []string{"didomi"}},
+	{"Quantcast Choice", []string{"cmp.quantcast.com", "quantcast.mgr.consensu.org"}, []string{"qc-cmp2"}},
+	{"TrustArc", []string{"consent.trustarc.com", "consent-pref.trustarc.com"}, []string{"truste-", "trustarc"}},
+	{"Osano", []string{"cmp.osano.com"}, []string{"osano-cm"}},
+	{"Termly", []string{"app.termly.io"}, []string{"termly"}},
+	{"CookieYes", []string{"cdn-cookieyes.com"}, []string{"cookieyes", "cky-consent"}},
+	{"iubenda", []string{"cdn.iubenda.com"}, []string{"iubenda-cs"}},
+	{"Complianz", nil, []string{"cmplz-"}},
+	{"Klaro", []string{"cdn.kiprotect.com/klaro"}, []string{"klaro"}},
+}
+
+// consentContainerKeywords match ids and classes of hand-rolled banners
+var consentContainerKeywords = []string{"cookie", "consent", "gdpr", "privacy-banner", "privacy-notice"}
+
+// cookieTextKeywords match the banner text in several languages
+var cookieTextKeywords = []string{
+	"cookie", "tracking", "consent",
+	"datenschutz", "einwilligung",
+	"témoins", "consentement",
+	"consentimiento",
+	"consenso",
+	"toestemming",
+	"ciasteczk", "zgod",
+	"kakor", "samtycke",
+	"evästee",
+}
+
+// consentButtonKeywords match accept and reject controls in several languages
+var consentButtonKeywords = []string{
+	"accept", "agree", "allow", "got it", "reject", "decline", "deny",
+	"akzeptieren", "zustimmen", "zulassen", "ablehnen", "einverstanden",
+	"accepter", "j'accepte", "refuser", "autoriser",
+	"aceptar", "rechazar", "permitir",
+	"accetta", "accetto", "rifiuta",
+	"aceitar", "concordo", "rejeitar",
+	"accepteren", "akkoord", "weigeren",
+	"akceptuj", "zgadzam", "odrzuć",
+	"godkänn", "acceptera", "neka",
+	"hyväksy", "hylkää",
+}
+
+// detectConsent looks for a consent management platform or a hand-rolled
+// consent banner, and flags trackers loaded when neither is present. Banners
+// injected entirely by an unknown script cannot be seen in the static markup.
+func detectConsent(doc *html.Node, baseURL *url.URL, trackers models.TrackerReport) models.ConsentReport {
+	platforms := make(map[string]*models.ConsentPlatform)
+	report := models.ConsentReport{
+		Platforms:           []models.ConsentPlatform{},
+		BannerEvidence:      []string{},
+		UnconsentedTrackers: []string{},
+	}
+
+	record := func(name, evidence string) {
+		p, ok := platforms[name]
+		if !ok {
+			p = &models.ConsentPlatform{Name: name, Evidence: []string{}}
+			platforms[name] = p
+		}
+		if !containsString(p.Evidence, evidence) {
+			p.Evidence = append(p.Evidence, evidence)
+		}
+	}
+
+	inspectURL := func(kind, raw string) {
+		u, err := baseURL.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return
+		}
+		for _, platform := range consentPlatforms {
+			if matchesEndpoint(platform.endpoints, u) {
+				record(platform.name, kind+":"+u.String())
+				return
+			}
+		}
+	}
+
+	inspectMarkers := func(kind, value string) {
+		lower := strings.ToLower(value)
+		for _, platform := range consentPlatforms {
+			for _, marker := range platform.markers {
+				if strings.Contains(lower, marker) {
+					record(platform.name, kind+":"+value)
+					break
+				}
+			}
+		}
+	}
+
+	inspectSnippet := func(script string) {
+		lower := strings.ToLower(script)
+		for _, platform := range consentPlatforms {
+			for _, marker := range platform.markers {
+				if strings.Contains(lower, marker) {
+					record(platform.name, "inline:"+marker)
+					break
+				}
+			}
+		}
+		// The IAB framework API is what every TCF-registered platform exposes
+		if strings.Contains(script, "__tcfapi") {
+			record("IAB TCF", "inline:__tcfapi")
+		}
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script":
+				if src := getAttr(n, "src"); src != "" {
+					inspectURL("script", src)
+				} else if isJavaScriptType(getAttr(n, "type")) {
+					inspectSnippet(textContent(n))
+				}
+				if id := getAttr(n, "id"); id != "" {
+					inspectMarkers("id", id)
+				}
+			case "link", "iframe":
+				if src := getAttr(n, "src") + getAttr(n, "href"); src != "" {
+					inspectURL(n.Data, src)
+				}
+			default:
+				if id := getAttr(n, "id"); id != "" {
+					inspectMarkers("id", id)
+				}
+				if class := getAttr(n, "class"); class != "" {
+					inspectMarkers("class", class)
+				}
+				if evidence, ok := consentBanner(n); ok && !containsString(report.BannerEvidence, evidence) {
+					report.BannerEvidence = append(report.BannerEvidence, evidence)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+
+	for _, p := range platforms {
+		report.Platforms = append(report.Platforms, *p)
+	}
+	sort.Slice(report.Platforms, func(i, j int) bool {
+		return report.Platforms[i].Name < report.Platforms[j].Name
+	})
+	report.CustomBanner = len(report.BannerEvidence) > 0
+	report.Detected = len(report.Platforms) > 0 || report.CustomBanner
+
+	if !report.Detected {
+		for _, tracker := range trackers.Trackers {
+			report.UnconsentedTrackers = append(report.UnconsentedTrackers, tracker.Vendor)
+		}
+		report.TrackersWithoutConsent = len(report.UnconsentedTrackers) > 0
+	}
+
+	return report
+}
+
+// consentBanner reports whether n looks like a hand-rolled consent banner:
+// a container named like one, or a dialog, that mentions cookies or consent
+// and offers a control to accept or reject
+func consentBanner(n *html.Node) (string, bool) {
+	switch n.Data {
+	case "div", "section", "aside", "dialog", "form", "footer", "header", "nav":
+	default:
+		return "", false
+	}
+
+	identity := getAttr(n, "id") + " " + getAttr(n, "class") + " " + getAttr(n, "aria-label")
+	role := strings.ToLower(getAttr(n, "role"))
+	if !containsKeyword(identity, consentContainerKeywords) &&
+		n.Data != "dialog" && role != "dialog" && role != "alertdialog" {
+		return "", false
+	}
+	if !containsKeyword(textContent(n), cookieTextKeywords) {
+		return "", false
+	}
+
+	var control *html.Node
+	var findControl func(*html.Node)
+	findControl = func(c *html.Node) {
+		if control != nil {
+			return
+		}
+		if c.Type == html.ElementNode {
+			label := ""
+			switch c.Data {
+			case "button", "a":
+				label = textContent(c)
+			case "input":
+				label = getAttr(c, "value")
+			}
+			if label != "" && containsKeyword(label, consentButtonKeywords) {
+				control = c
+				return
+			}
+		}
+		for child := c.FirstChild; child != nil; child = child.NextSibling {
+			findControl(child)
+		}
+	}
+	findControl(n)
+	if control == nil {
+		return "", false
+	}
+
+	evidence := n.Data
+	if id := getAttr(n, "id"); id != "" {
+		evidence += "#" + id
+	} else if class := strings.Fields(getAttr(n, "class")); len(class) > 0 {
+		evidence += "." + class[0]
+	}
+	return evidence + ":" + textContent(control), true
+}
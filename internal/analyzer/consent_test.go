@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// TestDetectConsent tests consent platform and banner detection
+func TestDetectConsent(t *testing.T) {
+	baseURL, _ := url.Parse("https://www.example.com/")
+	gtag := models.TrackerReport{Trackers: []models.Tracker{{Vendor: "Google Analytics", Category: "analytics"}}}
+
+	testCases := []struct {
+		name            string
+		html            string
+		trackers        models.TrackerReport
+		wantPlatforms   []string
+		wantCustom      bool
+		wantUnconsented []string
+		wantWithoutCMP  bool
+	}{
+		{
+			name:          "OneTrust script",
+			html:          `<html><head><script src="https://cdn.cookielaw.org/scripttemplates/otSDKStub.js" data-domain-script="abc"></script></head><body></body></html>`,
+			trackers:      gtag,
+			wantPlatforms: []string{"OneTrust"},
+		},
+		{
+			name:          "Cookiebot script id",
+			html:          `<html><head><script id="Cookiebot" src="https://consent.cookiebot.com/uc.js" data-cbid="x"></script></head><body></body></html>`,
+			wantPlatforms: []string{"Cookiebot"},
+		},
+		{
+			name:          "Usercentrics root element",
+			html:          `<html><body><div id="usercentrics-root"></div></body></html>`,
+			wantPlatforms: []string{"Usercentrics"},
+		},
+		{
+			name:          "TCF API stub",
+			html:          `<html><head><script>window.__tcfapi = window.__tcfapi || function(){};</script></head><body></body></html>`,
+			wantPlatforms: []string{"IAB TCF"},
+		},
+		{
+			name: "Custom English banner",
+			html: `<html><body><div class="cookie-notice">
+				<p>We use cookies to improve your experience.</p>
+				<button>Accept all</button>
+			</div></body></html>`,
+			trackers:   gtag,
+			wantCustom: true,
+		},
+		{
+			name: "Custom German dialog",
+			html: `<html><body><div role="dialog">
+				<p>Wir verwenden Cookies und ähnliche Technologien.</p>
+				<a href="#">Alle akzeptieren</a>
+			</div></body></html>`,
+			wantCustom: true,
+		},
+		{
+			name: "Custom French banner",
+			html: `<html><body><section id="bandeau-consentement">
+				<p>Ce site utilise des témoins de connexion.</p>
+				<button>Tout refuser</button>
+			</section></body></html>`,
+			wantCustom: true,
+		},
+		{
+			name:            "Trackers without consent",
+			html:            `<html><body><div class="cookie-policy-link"><a href="/cookies">Cookie policy</a></div></body></html>`,
+			trackers:        gtag,
+			wantUnconsented: []string{"Google Analytics"},
+			wantWithoutCMP:  true,
+		},
+		{
+			name: "No trackers and no banner",
+			html: `<html><body><p>Hello</p></body></html>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tc.html))
+			require.NoError(t, err)
+
+			report := detectConsent(doc, baseURL, tc.trackers)
+
+			var platforms []string
+			for _, p := range report.Platforms {
+				platforms = append(platforms, p.Name)
+				assert.NotEmpty(t, p.Evidence)
+			}
+			assert.Equal(t, tc.wantPlatforms, platforms)
+			assert.Equal(t, tc.wantCustom, report.CustomBanner)
+			assert.Equal(t, len(tc.wantPlatforms) > 0 || tc.wantCustom, report.Detected)
+			assert.Equal(t, tc.wantWithoutCMP, report.TrackersWithoutConsent)
+			if tc.wantUnconsented == nil {
+				assert.Empty(t, report.UnconsentedTrackers)
+			} else {
+				assert.Equal(t, tc.wantUnconsented, report.UnconsentedTrackers)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// wordsPerMinute is the average silent reading speed of adults
+const wordsPerMinute = 238
+
+// minParagraphLength is the text length below which a paragraph does not
+// count towards the content score of its ancestors
+const minParagraphLength = 25
+
+// boilerplateElements never hold main content
+var boilerplateElements = toSet(
+	"nav", "aside", "script", "style", "noscript", "template", "svg", "canvas",
+	"iframe", "object", "embed", "button", "select", "textarea", "input",
+)
+
+// boilerplateRoles are the ARIA landmarks around the main content
+var boilerplateRoles = toSet("navigation", "banner", "contentinfo", "complementary", "search")
+
+// boilerplateTokens in a class or id mark page furniture
+var boilerplateTokens = toSet(
+	"sidebar", "comment", "comments", "share", "social", "related", "cookie",
+	"breadcrumb", "breadcrumbs", "newsletter", "promo", "ad", "ads", "advert",
+	"menu", "footer", "header", "nav", "widget", "popup", "modal",
+)
+
+// contentTokens in a class or id mark the main content
+var contentTokens = toSet("article", "content", "main", "post", "entry", "body", "text", "story", "blog")
+
+// paragraphElements are scored by their text; a div counts only when it
+// holds no block children
+var paragraphElements = toSet("p", "pre", "blockquote", "td", "div")
+
+// textBlockElements start a new line in the extracted text
+var textBlockElements = toSet(
+	"address", "article", "blockquote", "br", "dd", "details", "div", "dl", "dt",
+	"figcaption", "figure", "footer", "h1", "h2", "h3", "h4", "h5", "h6", "header",
+	"hr", "li", "main", "ol", "p", "pre", "section", "summary", "table", "td",
+	"th", "tr", "ul",
+)
+
+// analyzeContent extracts the main content of the page and reports its text
+// statistics, language and readability. scorers maps language codes to
+// readability scorers; nil means DefaultReadabilityScorers.
+func analyzeContent(doc *html.Node, body []byte, scorers map[string]ReadabilityScorer) models.ContentReport {
+	if scorers == nil {
+		scorers = DefaultReadabilityScorers()
+	}
+	report := models.ContentReport{Readability: []models.ReadabilityScore{}}
+
+	root := mainContent(doc)
+	if root == nil {
+		return report
+	}
+	report.Element = root.Data
+	report.Text = contentText(root)
+
+	words := tokenizeWords(report.Text)
+	report.WordCount = len(words)
+	for _, line := range strings.Split(report.Text, "\n") {
+		report.SentenceCount += len(splitSentences(line))
+	}
+	if len(body) > 0 {
+		report.TextToHTMLRatio = math.Round(float64(len(report.Text))/float64(len(body))*10000) / 100
+	}
+	report.ReadingTimeMinutes = (report.WordCount + wordsPerMinute - 1) / wordsPerMinute
+
+	if n := findFirst(doc, "html"); n != nil {
+		report.DeclaredLanguage = strings.TrimSpace(getAttr(n, "lang"))
+	}
+	report.Language, report.LanguageConfidence = detectLanguage(words)
+
+	language := report.Language
+	if language == "" {
+		language, _, _ = strings.Cut(strings.ToLower(report.DeclaredLanguage), "-")
+	}
+	if scorer, ok := scorers[language]; ok && report.WordCount > 0 {
+		if scores := scorer.Score(report.Text); scores != nil {
+			report.Readability = scores
+		}
+	}
+
+	return report
+}
+
+// mainContent picks the element holding the main content: <main> or the
+// main landmark, a lone <article>, or else the element whose paragraphs
+// score highest on text length, commas and link density
+func mainContent(doc *html.Node) *html.Node {
+	body := findFirst(doc, "body")
+	if body == nil {
+		return nil
+	}
+
+	var main *html.Node
+	var articles []*html.Node
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if n.Data == "main" || strings.EqualFold(getAttr(n, "role"), "main") {
+				if main == nil {
+					main = n
+				}
+				return
+			}
+			if n.Data == "article" {
+				articles = append(articles, n)
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(body)
+	switch {
+	case main != nil:
+		return main
+	case len(articles) == 1:
+		return articles[0]
+	}
+
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	credit := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+	var score func(*html.Node, bool)
+	score = func(n *html.Node, inArticle bool) {
+		if n != body && isBoilerplate(n, inArticle) {
+			return
+		}
+		if paragraphElements[n.Data] && (n.Data != "div" || !hasBlockChild(n)) {
+			if text := textContent(n); len(text) >= minParagraphLength {
+				points := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+				credit(n.Parent, points)
+				credit(n.Parent.Parent, points/2)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode {
+				score(c, inArticle || n.Data == "article")
+			}
+		}
+	}
+	score(body, false)
+
+	best, bestScore := body, 0.0
+	for _, n := range candidates {
+		total := scores[n]*(1-linkDensity(n)) + classWeight(n)
+		if total > bestScore {
+			best, bestScore = n, total
+		}
+	}
+	return best
+}
+
+// isBoilerplate reports whether n is navigation, a landmark around the main
+// content, hidden, or page furniture by its class or id. Headers and footers
+// of an article belong to the article.
+func isBoilerplate(n *html.Node, inArticle bool) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if boilerplateElements[n.Data] || boilerplateRoles[strings.ToLower(getAttr(n, "role"))] {
+		return true
+	}
+	if (n.Data == "header" || n.Data == "footer") && !inArticle {
+		return true
+	}
+	if _, hidden := getAttrOK(n, "hidden"); hidden || strings.EqualFold(getAttr(n, "aria-hidden"), "true") {
+		return true
+	}
+	if style := strings.ToLower(strings.ReplaceAll(getAttr(n, "style"), " ", "")); strings.Contains(style, "display:none") {
+		return true
+	}
+	for _, token := range classTokens(n) {
+		if boilerplateTokens[token] {
+			return true
+		}
+	}
+	return false
+}
+
+// classWeight favors elements whose class or id names the content
+func classWeight(n *html.Node) float64 {
+	for _, token := range classTokens(n) {
+		if contentTokens[token] {
+			return 5
+		}
+	}
+	return 0
+}
+
+// classTokens splits the class and id of n into lowercase words
+func classTokens(n *html.Node) []string {
+	return strings.FieldsFunc(strings.ToLower(getAttr(n, "class")+" "+getAttr(n, "id")), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// linkDensity is the share of the text of n that sits inside links
+func linkDensity(n *html.Node) float64 {
+	total := len(textContent(n))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.Data == "a" {
+			linked += len(textContent(node))
+			return
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+	return float64(linked) / float64(total)
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && textBlockElements[c.Data] && c.Data != "br" {
+			return true
+		}
+	}
+	return false
+}
+
+// contentText returns the text of root without its boilerplate, one line
+// per block
+func contentText(root *html.Node) string {
+	var b strings.Builder
+	var collect func(*html.Node, bool)
+	collect = func(n *html.Node, inArticle bool) {
+		if n != root && isBoilerplate(n, inArticle) {
+			return
+		}
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			return
+		}
+		block := n.Type == html.ElementNode && textBlockElements[n.Data]
+		if block {
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c, inArticle || n.Data == "article")
+		}
+		if block {
+			b.WriteString("\n")
+		}
+	}
+	collect(root, root.Data == "article")
+
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tokenizeWords splits text into words, keeping inner apostrophes and
+// hyphens as in "don't" and "e-mail"
+func tokenizeWords(text string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !isWordJoiner(r)
+	}) {
+		if word := strings.TrimFunc(field, isWordJoiner); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+func isWordJoiner(r rune) bool {
+	return r == '\'' || r == '’' || r == '-'
+}
+
+// splitSentences splits a line at sentence-ending punctuation followed by a
+// space or the end of the line, keeping the sentences that have a word
+func splitSentences(line string) []string {
+	var sentences []string
+	runes := []rune(line)
+	start := 0
+	for i, r := range runes {
+		if !strings.ContainsRune(".!?…", r) || (i+1 < len(runes) && !unicode.IsSpace(runes[i+1])) {
+			continue
+		}
+		if sentence := strings.TrimSpace(string(runes[start : i+1])); len(tokenizeWords(sentence)) > 0 {
+			sentences = append(sentences, sentence)
+		}
+		start = i + 1
+	}
+	if rest := strings.TrimSpace(string(runes[start:])); len(tokenizeWords(rest)) > 0 {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// detectLanguage names the language whose stop words are most frequent in
+// words, with the share of the stop word hits it accounts for. Fewer than
+// three hits or a tie leave the language unknown.
+func detectLanguage(words []string) (string, float64) {
+	hits := make(map[string]int)
+	total := 0
+	for _, word := range words {
+		word = strings.ToLower(word)
+		for language, set := range stopWords {
+			if set[word] {
+				hits[language]++
+				total++
+			}
+		}
+	}
+
+	best, bestHits, secondHits := "", 0, 0
+	for language, count := range hits {
+		switch {
+		case count > bestHits || (count == bestHits && language < best):
+			best, bestHits, secondHits = language, count, bestHits
+		case count > secondHits:
+			secondHits = count
+		}
+	}
+	if bestHits < 3 || bestHits == secondHits {
+		return "", 0
+	}
+	return best, math.Round(float64(bestHits)/float64(total)*100) / 100
+}
+
+// findFirst returns the first element with the given tag name
+func findFirst(n *html.Node, tagName string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tagName {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findFirst(c, tagName); found != nil {
+			return found
+		}
+	}
+	return nil
+}
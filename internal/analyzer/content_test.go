@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+const articlePage = `<!DOCTYPE html>
+<html lang="en-GB"><head><title>Gardening</title><style>p { color: red }</style></head>
+<body>
+	<header><a href="/">Home</a> <a href="/blog">Blog</a></header>
+	<nav><ul><li><a href="/a">Section A with a long navigation label</a></li></ul></nav>
+	<div class="layout">
+		<div class="sidebar-left"><p>Subscribe to our newsletter, get offers, discounts and more news.</p></div>
+		<div class="post-body">
+			<h1>Growing tomatoes</h1>
+			<p>Tomatoes need sun, water and patience. They grow best in warm soil, and the plants should be staked early.</p>
+			<p>Water them at the base in the morning. Avoid wetting the leaves, because that spreads disease.</p>
+			<p style="display: none">Hidden text that is not part of the article at all.</p>
+			<script>trackPageView()</script>
+		</div>
+		<div class="comments"><p>Great article, thanks for sharing it with all of us here!</p></div>
+	</div>
+	<footer><p>Copyright 2024, Example Gardens, all rights reserved worldwide.</p></footer>
+</body></html>`
+
+// TestMainContent tests which element is taken as the main content
+func TestMainContent(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        string
+		wantElement string
+		wantText    string
+	}{
+		{
+			name:        "Main element",
+			body:        `<body><nav>Menu</nav><main><p>Main text.</p></main><article>Other</article></body>`,
+			wantElement: "main",
+			wantText:    "Main text.",
+		},
+		{
+			name:        "Main role",
+			body:        `<body><div role="main"><p>Role text.</p><aside>Aside</aside></div></body>`,
+			wantElement: "div",
+			wantText:    "Role text.",
+		},
+		{
+			name:        "Single article keeps its header",
+			body:        `<body><header>Site</header><article><header><h1>Title</h1></header><p>Body.</p></article></body>`,
+			wantElement: "article",
+			wantText:    "Title\nBody.",
+		},
+		{
+			name:        "Scored paragraphs",
+			body:        articlePage,
+			wantElement: "div",
+			wantText: "Growing tomatoes\n" +
+				"Tomatoes need sun, water and patience. They grow best in warm soil, and the plants should be staked early.\n" +
+				"Water them at the base in the morning. Avoid wetting the leaves, because that spreads disease.",
+		},
+		{
+			name: "Form-wrapped page",
+			body: `<body><form id="aspnetForm" method="post">
+				<input type="hidden" name="__VIEWSTATE" value="dDwtMTA4">
+				<nav><a href="/">Home</a></nav>
+				<div class="post"><h1>Pruning roses</h1>
+				<p>Prune roses in late winter, just before the buds break. Cut above an outward facing bud.</p>
+				<p>Remove dead, damaged and crossing stems first, then thin the centre to let in light and air.</p></div>
+			</form></body>`,
+			wantElement: "div",
+			wantText: "Pruning roses\n" +
+				"Prune roses in late winter, just before the buds break. Cut above an outward facing bud.\n" +
+				"Remove dead, damaged and crossing stems first, then thin the centre to let in light and air.",
+		},
+		{
+			name:        "Body fallback",
+			body:        `<body><span>Short</span></body>`,
+			wantElement: "body",
+			wantText:    "Short",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			root := mainContent(doc)
+			require.NotNil(t, root)
+			assert.Equal(t, tc.wantElement, root.Data)
+			assert.Equal(t, tc.wantText, contentText(root))
+		})
+	}
+}
+
+// TestAnalyzeContent tests the statistics reported for the main content
+func TestAnalyzeContent(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(articlePage))
+	require.NoError(t, err)
+
+	report := analyzeContent(doc, []byte(articlePage), nil)
+
+	assert.Equal(t, "div", report.Element)
+	assert.Equal(t, 37, report.WordCount)
+	assert.Equal(t, 5, report.SentenceCount)
+	assert.Equal(t, 1, report.ReadingTimeMinutes)
+	assert.Greater(t, report.TextToHTMLRatio, 0.0)
+	assert.Less(t, report.TextToHTMLRatio, 100.0)
+	assert.Equal(t, "en", report.Language)
+	assert.Greater(t, report.LanguageConfidence, 0.5)
+	assert.Equal(t, "en-GB", report.DeclaredLanguage)
+
+	names := make([]string, len(report.Readability))
+	for i, score := range report.Readability {
+		names[i] = score.Name
+	}
+	assert.Equal(t, []string{
+		"flesch-reading-ease", "flesch-kincaid-grade", "gunning-fog", "smog",
+		"coleman-liau", "automated-readability-index",
+	}, names)
+
+	// A custom scorer replaces the defaults
+	custom := map[string]ReadabilityScorer{
+		"en": ReadabilityScorerFunc(func(text string) []models.ReadabilityScore {
+			return []models.ReadabilityScore{{Name: "custom", Score: float64(len(text))}}
+		}),
+	}
+	report = analyzeContent(doc, []byte(articlePage), custom)
+	require.Len(t, report.Readability, 1)
+	assert.Equal(t, "custom", report.Readability[0].Name)
+
+	// Without a detected language the declared one picks the scorer
+	doc, _ = html.Parse(strings.NewReader(`<html lang="en"><body><p>Tomatoes grow.</p></body></html>`))
+	report = analyzeContent(doc, nil, nil)
+	assert.Empty(t, report.Language)
+	assert.NotEmpty(t, report.Readability)
+
+	doc, _ = html.Parse(strings.NewReader(`<html lang="ja"><body><p>Tomatoes grow.</p></body></html>`))
+	report = analyzeContent(doc, nil, nil)
+	assert.Empty(t, report.Readability)
+}
+
+// TestDetectLanguage tests stop word based language identification
+func TestDetectLanguage(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"English", "The cat is on the roof and it is not happy about the rain", "en"},
+		{"German", "Der Hund ist nicht in dem Haus, aber er wird bald wieder da sein", "de"},
+		{"French", "Le chat est sur le toit et il ne veut pas descendre avec nous", "fr"},
+		{"Spanish", "El perro está en la casa y no quiere salir con los niños porque llueve", "es"},
+		{"Too few hits", "Tomatoes potatoes carrots", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			language, _ := detectLanguage(tokenizeWords(tc.text))
+			assert.Equal(t, tc.want, language)
+		})
+	}
+}
+
+// TestTokenizeWords tests word and sentence splitting
+func TestTokenizeWords(t *testing.T) {
+	assert.Equal(t, []string{"Don't", "e-mail", "me", "at", "3", "o'clock"},
+		tokenizeWords("Don't e-mail me -- at 3 o'clock!"))
+	assert.Equal(t, []string{"One.", "Two?", "Version 2.0 is out…", "No end"},
+		splitSentences("One. Two? Version 2.0 is out… No end"))
+	assert.Empty(t, splitSentences(" ... "))
+}
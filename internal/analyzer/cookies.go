@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// sessionCookiePattern matches cookie names that usually carry a session or
+// credential. CSRF tokens are meant to be read by scripts and are excluded.
+var (
+	sessionCookiePattern = regexp.MustCompile(`(?i)(sess|sid$|^sid|auth|token|jwt|login|remember)`)
+	csrfCookiePattern    = regexp.MustCompile(`(?i)(csrf|xsrf)`)
+)
+
+// analyzeCookies parses every Set-Cookie header of the page response and of
+// the redirect responses that led to it
+func analyzeCookies(resp *http.Response, pageURL *url.URL) models.CookieReport {
+	report := models.CookieReport{
+		ThirdPartyDomains: []string{},
+		Cookies:           []models.CookieInfo{},
+		Findings:          []models.CookieFinding{},
+	}
+	pageHost := strings.ToLower(pageURL.Hostname())
+	thirdPartyDomains := make(map[string]bool)
+
+	// Walk back from the final response through the redirect chain, then
+	// report cookies in the order the browser would have received them
+	var hops []*http.Response
+	for r := resp; r != nil; {
+		hops = append([]*http.Response{r}, hops...)
+		if r.Request == nil {
+			break
+		}
+		r = r.Request.Response
+	}
+
+	for _, hop := range hops {
+		setBy := pageHost
+		if hop.Request != nil && hop.Request.URL != nil {
+			setBy = strings.ToLower(hop.Request.URL.Hostname())
+		}
+
+		for _, cookie := range hop.Cookies() {
+			info := cookieInfo(cookie, setBy)
+			info.ThirdParty = !isFirstPartyHost(info.Domain, pageHost)
+			if info.ThirdParty && !thirdPartyDomains[info.Domain] {
+				thirdPartyDomains[info.Domain] = true
+				report.ThirdPartyDomains = append(report.ThirdPartyDomains, info.Domain)
+			}
+
+			report.Cookies = append(report.Cookies, info)
+			report.Findings = append(report.Findings, cookieFindings(info, setBy != pageHost)...)
+			if cookie.Domain != "" {
+				if issue := cookieDomainIssue(info.Domain, setBy); issue != "" {
+					report.Findings = append(report.Findings, models.CookieFinding{Cookie: info.Name, Issue: issue})
+				}
+			}
+		}
+	}
+
+	sort.Strings(report.ThirdPartyDomains)
+	report.Total = len(report.Cookies)
+
+	return report
+}
+
+func cookieInfo(cookie *http.Cookie, setBy string) models.CookieInfo {
+	domain := strings.ToLower(strings.TrimPrefix(cookie.Domain, "."))
+	if domain == "" {
+		domain = setBy
+	}
+
+	info := models.CookieInfo{
+		Name:     cookie.Name,
+		Domain:   domain,
+		Path:     cookie.Path,
+		Secure:   cookie.Secure,
+		HttpOnly: cookie.HttpOnly,
+		SameSite: sameSiteName(cookie.SameSite),
+		MaxAge:   cookie.MaxAge,
+		SetBy:    setBy,
+	}
+	if !cookie.Expires.IsZero() {
+		expires := cookie.Expires.UTC()
+		info.Expires = &expires
+	}
+	info.Session = info.Expires == nil && cookie.MaxAge == 0
+
+	return info
+}
+
+// cookieDomainIssue checks the Domain attribute of a cookie against the host
+// that set it, as browsers do before storing the cookie
+func cookieDomainIssue(domain, setBy string) string {
+	if domain != setBy && !(strings.HasSuffix(setBy, "."+domain) && net.ParseIP(setBy) == nil) {
+		return "Domain=" + domain + " does not cover " + setBy + ", the host that set it; browsers reject it"
+	}
+	if suffix, _ := publicsuffix.PublicSuffix(domain); suffix == domain && domain != setBy {
+		return "Domain=" + domain + " is a public suffix; browsers reject it"
+	}
+	return ""
+}
+
+func sameSiteName(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	case http.SameSiteDefaultMode:
+		return "Default"
+	}
+	return ""
+}
+
+func cookieFindings(info models.CookieInfo, viaRedirect bool) []models.CookieFinding {
+	var findings []models.CookieFinding
+	add := func(issue string) {
+		findings = append(findings, models.CookieFinding{Cookie: info.Name, Issue: issue})
+	}
+
+	if sessionCookiePattern.MatchString(info.Name) && !csrfCookiePattern.MatchString(info.Name) {
+		if !info.Secure {
+			add("session cookie without Secure")
+		}
+		if !info.HttpOnly {
+			add("session cookie without HttpOnly")
+		}
+	}
+	if info.SameSite == "None" && !info.Secure {
+		add("SameSite=None requires Secure")
+	}
+	if info.ThirdParty && viaRedirect {
+		add("third-party cookie for " + info.Domain + " set during redirect through " + info.SetBy)
+	}
+	if info.Expires != nil && info.Expires.After(time.Now().AddDate(1, 1, 0)) {
+		add("expires more than 13 months from now")
+	}
+
+	return findings
+}
@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// TestAnalyzeCookiesThroughRedirect tests cookies set by the page and by a redirect hop
+func TestAnalyzeCookiesThroughRedirect(t *testing.T) {
+	redirect := &http.Response{
+		StatusCode: http.StatusFound,
+		Header: http.Header{
+			"Location":   {"https://www.example.com/"},
+			"Set-Cookie": {"uid=abc; Domain=.tracker.net; Path=/; SameSite=None"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString("")),
+	}
+	page := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"text/html"},
+			"Set-Cookie": {
+				"sessionid=s3cr3t; Path=/; HttpOnly",
+				"prefs=dark; Path=/; Secure; Max-Age=3600; SameSite=Lax",
+				"csrftoken=t0k3n; Path=/; Secure",
+			},
+		},
+		Body: io.NopCloser(strings.NewReader(`<html><body>Hi</body></html>`)),
+	}
+
+	client := &http.Client{
+		Transport: requestRecordingTransport{
+			"https://tracker.net/r":    redirect,
+			"https://www.example.com/": page,
+		},
+	}
+
+	analyzer := &Analyzer{client: client}
+	result, err := analyzer.Analyze("https://tracker.net/r")
+	require.NoError(t, err)
+
+	report := result.Cookies
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, []string{"tracker.net"}, report.ThirdPartyDomains)
+
+	cookies := make(map[string]models.CookieInfo)
+	for _, c := range report.Cookies {
+		cookies[c.Name] = c
+	}
+	assert.Equal(t, "uid", report.Cookies[0].Name, "redirect cookies come first")
+
+	assert.True(t, cookies["uid"].ThirdParty)
+	assert.Equal(t, "tracker.net", cookies["uid"].SetBy)
+	assert.Equal(t, "None", cookies["uid"].SameSite)
+
+	assert.False(t, cookies["sessionid"].ThirdParty)
+	assert.Equal(t, "www.example.com", cookies["sessionid"].Domain)
+	assert.True(t, cookies["sessionid"].HttpOnly)
+	assert.True(t, cookies["sessionid"].Session)
+
+	assert.Equal(t, 3600, cookies["prefs"].MaxAge)
+	assert.False(t, cookies["prefs"].Session)
+
+	var issues []string
+	for _, f := range report.Findings {
+		issues = append(issues, f.Cookie+": "+f.Issue)
+	}
+	assert.ElementsMatch(t, []string{
+		"uid: SameSite=None requires Secure",
+		"uid: third-party cookie for tracker.net set during redirect through tracker.net",
+		"sessionid: session cookie without Secure",
+	}, issues)
+}
+
+// TestAnalyzeCookiesNone tests a response without cookies
+func TestAnalyzeCookiesNone(t *testing.T) {
+	pageURL, _ := url.Parse("https://example.com/")
+	report := analyzeCookies(&http.Response{Header: http.Header{}}, pageURL)
+
+	assert.Equal(t, 0, report.Total)
+	assert.Empty(t, report.Cookies)
+	assert.Empty(t, report.Findings)
+}
+
+// TestCookieDomainIssue tests Domain attributes browsers would reject
+func TestCookieDomainIssue(t *testing.T) {
+	testCases := []struct {
+		domain string
+		setBy  string
+		want   string
+	}{
+		{"example.com", "www.example.com", ""},
+		{"www.example.com", "www.example.com", ""},
+		{"other.com", "www.example.com", "Domain=other.com does not cover www.example.com, the host that set it; browsers reject it"},
+		{"ample.com", "www.example.com", "Domain=ample.com does not cover www.example.com, the host that set it; browsers reject it"},
+		{"shop.example.com", "www.example.com", "Domain=shop.example.com does not cover www.example.com, the host that set it; browsers reject it"},
+		{"co.uk", "www.example.co.uk", "Domain=co.uk is a public suffix; browsers reject it"},
+		{"0.1", "10.0.0.1", "Domain=0.1 does not cover 10.0.0.1, the host that set it; browsers reject it"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, cookieDomainIssue(tc.domain, tc.setBy), tc.domain)
+	}
+
+	pageURL, _ := url.Parse("https://www.example.com/")
+	report := analyzeCookies(&http.Response{Header: http.Header{
+		"Set-Cookie": {"a=1; Domain=other.com; Secure", "b=2; Secure"},
+	}}, pageURL)
+	assert.Equal(t, []models.CookieFinding{
+		{Cookie: "a", Issue: "Domain=other.com does not cover www.example.com, the host that set it; browsers reject it"},
+	}, report.Findings)
+}
+
+// requestRecordingTransport links each response to its request like a real
+// transport does, which the client needs to expose the redirect chain
+type requestRecordingTransport map[string]*http.Response
+
+func (m requestRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, ok := m[req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString("")), Request: req}, nil
+	}
+	linked := *resp
+	linked.Request = req
+	return &linked, nil
+}
@@ -0,0 +1,248 @@
+package analyzer
+
+import (
+	"mime"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// Rendering modes, named after the HTML spec's no-quirks, limited-quirks and
+// quirks document modes
+const (
+	renderingStandards       = "standards"
+	renderingAlmostStandards = "almost-standards"
+	renderingQuirks          = "quirks"
+)
+
+// knownDoctype is a published document type and its identifiers
+type knownDoctype struct {
+	version  string
+	variant  string
+	publicID string
+	systemID string
+}
+
+// knownDoctypes lists the published public identifiers with their canonical
+// system identifier
+var knownDoctypes = []knownDoctype{
+	{"HTML 2.0", "", "-//IETF//DTD HTML 2.0//EN", ""},
+	{"HTML 2.0", "", "-//IETF//DTD HTML//EN", ""},
+	{"HTML 2.0", "Level 1", "-//IETF//DTD HTML 2.0 Level 1//EN", ""},
+	{"HTML 2.0", "Strict", "-//IETF//DTD HTML 2.0 Strict//EN", ""},
+	{"HTML 3.2", "", "-//W3C//DTD HTML 3.2 Final//EN", ""},
+	{"HTML 3.2", "", "-//W3C//DTD HTML 3.2//EN", ""},
+	{"HTML 4.0", "Strict", "-//W3C//DTD HTML 4.0//EN", "http://www.w3.org/TR/REC-html40/strict.dtd"},
+	{"HTML 4.0", "Transitional", "-//W3C//DTD HTML 4.0 Transitional//EN", "http://www.w3.org/TR/REC-html40/loose.dtd"},
+	{"HTML 4.0", "Frameset", "-//W3C//DTD HTML 4.0 Frameset//EN", "http://www.w3.org/TR/REC-html40/frameset.dtd"},
+	{"HTML 4.01", "Strict", "-//W3C//DTD HTML 4.01//EN", "http://www.w3.org/TR/html4/strict.dtd"},
+	{"HTML 4.01", "Transitional", "-//W3C//DTD HTML 4.01 Transitional//EN", "http://www.w3.org/TR/html4/loose.dtd"},
+	{"HTML 4.01", "Frameset", "-//W3C//DTD HTML 4.01 Frameset//EN", "http://www.w3.org/TR/html4/frameset.dtd"},
+	{"XHTML 1.0", "Strict", "-//W3C//DTD XHTML 1.0 Strict//EN", "http://www.w3.org/TR/xhtml1/DTD/xhtml1-strict.dtd"},
+	{"XHTML 1.0", "Transitional", "-//W3C//DTD XHTML 1.0 Transitional//EN", "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd"},
+	{"XHTML 1.0", "Frameset", "-//W3C//DTD XHTML 1.0 Frameset//EN", "http://www.w3.org/TR/xhtml1/DTD/xhtml1-frameset.dtd"},
+	{"XHTML 1.1", "", "-//W3C//DTD XHTML 1.1//EN", "http://www.w3.org/TR/xhtml11/DTD/xhtml11.dtd"},
+	{"XHTML Basic 1.0", "Basic", "-//W3C//DTD XHTML Basic 1.0//EN", "http://www.w3.org/TR/xhtml-basic/xhtml-basic10.dtd"},
+	{"XHTML Basic 1.1", "Basic", "-//W3C//DTD XHTML Basic 1.1//EN", "http://www.w3.org/TR/xhtml-basic/xhtml-basic11.dtd"},
+	{"XHTML Mobile 1.0", "Mobile", "-//WAPFORUM//DTD XHTML Mobile 1.0//EN", "http://www.wapforum.org/DTD/xhtml-mobile10.dtd"},
+	{"XHTML Mobile 1.1", "Mobile", "-//WAPFORUM//DTD XHTML Mobile 1.1//EN", "http://www.openmobilealliance.org/tech/DTD/xhtml-mobile11.dtd"},
+	{"XHTML Mobile 1.2", "Mobile", "-//WAPFORUM//DTD XHTML Mobile 1.2//EN", "http://www.openmobilealliance.org/tech/DTD/xhtml-mobile12.dtd"},
+	{"XHTML+RDFa 1.0", "RDFa", "-//W3C//DTD XHTML+RDFa 1.0//EN", "http://www.w3.org/MarkUp/DTD/xhtml-rdfa-1.dtd"},
+	{"XHTML+RDFa 1.1", "RDFa", "-//W3C//DTD XHTML+RDFa 1.1//EN", "http://www.w3.org/MarkUp/DTD/xhtml-rdfa-2.dtd"},
+	{"XHTML 1.1 plus MathML 2.0", "MathML", "-//W3C//DTD XHTML 1.1 plus MathML 2.0//EN", "http://www.w3.org/Math/DTD/mathml2/xhtml-math11-f.dtd"},
+	{"XHTML 1.1 plus MathML 2.0 plus SVG 1.1", "MathML+SVG", "-//W3C//DTD XHTML 1.1 plus MathML 2.0 plus SVG 1.1//EN", "http://www.w3.org/2002/04/xhtml-math-svg/xhtml-math-svg.dtd"},
+	{"MathML 2.0", "MathML", "-//W3C//DTD MathML 2.0//EN", "http://www.w3.org/Math/DTD/mathml2/mathml2.dtd"},
+	{"SVG 1.0", "SVG", "-//W3C//DTD SVG 1.0//EN", "http://www.w3.org/TR/2001/REC-SVG-20010904/DTD/svg10.dtd"},
+	{"SVG 1.1", "SVG", "-//W3C//DTD SVG 1.1//EN", "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd"},
+	{"SVG 1.1 Basic", "SVG", "-//W3C//DTD SVG 1.1 Basic//EN", "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11-basic.dtd"},
+	{"SVG 1.1 Tiny", "SVG", "-//W3C//DTD SVG 1.1 Tiny//EN", "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11-tiny.dtd"},
+}
+
+// quirksPublicIDs are the public identifiers that trigger quirks mode
+var quirksPublicIDs = []string{
+	"-//w3o//dtd w3 html strict 3.0//en//",
+	"-/w3c/dtd html 4.0 transitional/en",
+	"html",
+}
+
+// quirksPublicIDPrefixes are the public identifier prefixes that trigger quirks mode
+var quirksPublicIDPrefixes = []string{
+	"+//silmaril//dtd html pro v0r11 19970101//",
+	"-//as//dtd html 3.0 aswedit + extensions//",
+	"-//advasoft ltd//dtd html 3.0 aswedit + extensions//",
+	"-//ietf//dtd html 2.0 level 1//",
+	"-//ietf//dtd html 2.0 level 2//",
+	"-//ietf//dtd html 2.0 strict level 1//",
+	"-//ietf//dtd html 2.0 strict level 2//",
+	"-//ietf//dtd html 2.0 strict//",
+	"-//ietf//dtd html 2.0//",
+	"-//ietf//dtd html 2.1e//",
+	"-//ietf//dtd html 3.0//",
+	"-//ietf//dtd html 3.2 final//",
+	"-//ietf//dtd html 3.2//",
+	"-//ietf//dtd html 3//",
+	"-//ietf//dtd html level 0//",
+	"-//ietf//dtd html level 1//",
+	"-//ietf//dtd html level 2//",
+	"-//ietf//dtd html level 3//",
+	"-//ietf//dtd html strict level 0//",
+	"-//ietf//dtd html strict level 1//",
+	"-//ietf//dtd html strict level 2//",
+	"-//ietf//dtd html strict level 3//",
+	"-//ietf//dtd html strict//",
+	"-//ietf//dtd html//",
+	"-//metrius//dtd metrius presentational//",
+	"-//microsoft//dtd internet explorer 2.0 html strict//",
+	"-//microsoft//dtd internet explorer 2.0 html//",
+	"-//microsoft//dtd internet explorer 2.0 tables//",
+	"-//microsoft//dtd internet explorer 3.0 html strict//",
+	"-//microsoft//dtd internet explorer 3.0 html//",
+	"-//microsoft//dtd internet explorer 3.0 tables//",
+	"-//netscape comm. corp.//dtd html//",
+	"-//netscape comm. corp.//dtd strict html//",
+	"-//o'reilly and associates//dtd html 2.0//",
+	"-//o'reilly and associates//dtd html extended 1.0//",
+	"-//o'reilly and associates//dtd html extended relaxed 1.0//",
+	"-//sq//dtd html 2.0 hotmetal + extensions//",
+	"-//softquad software//dtd hotmetal pro 6.0::19990601::extensions to html 4.0//",
+	"-//softquad//dtd hotmetal pro 4.0::19971010::extensions to html 4.0//",
+	"-//spyglass//dtd html 2.0 extended//",
+	"-//sun microsystems corp.//dtd hotjava html//",
+	"-//sun microsystems corp.//dtd hotjava strict html//",
+	"-//w3c//dtd html 3 1995-03-24//",
+	"-//w3c//dtd html 3.2 draft//",
+	"-//w3c//dtd html 3.2 final//",
+	"-//w3c//dtd html 3.2//",
+	"-//w3c//dtd html 3.2s draft//",
+	"-//w3c//dtd html 4.0 frameset//",
+	"-//w3c//dtd html 4.0 transitional//",
+	"-//w3c//dtd html experimental 19960712//",
+	"-//w3c//dtd html experimental 970421//",
+	"-//w3c//dtd w3 html//",
+	"-//w3o//dtd w3 html 3.0//",
+	"-//webtechs//dtd mozilla html 2.0//",
+	"-//webtechs//dtd mozilla html//",
+}
+
+// analyzeDoctype identifies the document type and the rendering mode a browser
+// uses for it. Documents served as application/xhtml+xml go through the XML
+// parser, which always renders in standards mode.
+func analyzeDoctype(doc *html.Node, contentType string) models.DoctypeInfo {
+	info := models.DoctypeInfo{}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		info.ContentType = mediaType
+		info.ServedAsXHTML = mediaType == "application/xhtml+xml"
+	}
+
+	var doctype *html.Node
+	for child := doc.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.DoctypeNode {
+			doctype = child
+			break
+		}
+	}
+
+	if doctype == nil {
+		info.Version = "Unknown (No DOCTYPE)"
+		html5Elements := []string{"article", "aside", "audio", "canvas", "footer", "header", "nav", "section", "video"}
+		for _, element := range html5Elements {
+			if findElement(doc, element) {
+				info.Version = "HTML5 (No DOCTYPE)"
+				break
+			}
+		}
+		info.RenderingMode = renderingQuirks
+		if info.ServedAsXHTML {
+			info.RenderingMode = renderingStandards
+		}
+		return info
+	}
+
+	info.Present = true
+	info.Name = doctype.Data
+	publicID, hasPublic := getAttrOK(doctype, "public")
+	systemID, hasSystem := getAttrOK(doctype, "system")
+	info.PublicID = publicID
+	info.SystemID = systemID
+
+	info.Version, info.Variant, info.Known = doctypeVersion(doctype.Data, publicID, hasPublic, systemID, hasSystem)
+	info.RenderingMode = renderingMode(doctype.Data, publicID, hasPublic, systemID, hasSystem)
+	if info.ServedAsXHTML {
+		info.RenderingMode = renderingStandards
+	}
+
+	return info
+}
+
+// doctypeVersion maps the doctype identifiers to a version and variant
+func doctypeVersion(name, publicID string, hasPublic bool, systemID string, hasSystem bool) (string, string, bool) {
+	if name == "html" && !hasPublic && (!hasSystem || strings.EqualFold(systemID, "about:legacy-compat")) {
+		return "HTML5", "", true
+	}
+
+	for _, known := range knownDoctypes {
+		if strings.EqualFold(publicID, known.publicID) {
+			return known.version, known.variant, true
+		}
+	}
+	if hasSystem {
+		for _, known := range knownDoctypes {
+			if known.systemID != "" && strings.EqualFold(systemID, known.systemID) {
+				return known.version, known.variant, true
+			}
+		}
+	}
+
+	// Fall back to the version named in an unlisted public identifier
+	public := strings.ToLower(publicID)
+	switch {
+	case strings.Contains(public, "xhtml 1.0"):
+		return "XHTML 1.0", "", false
+	case strings.Contains(public, "xhtml 1.1"):
+		return "XHTML 1.1", "", false
+	case strings.Contains(public, "html 4"):
+		return "HTML 4.01", "", false
+	}
+	return "Unknown DOCTYPE", "", false
+}
+
+// renderingMode applies the HTML spec's rules for selecting quirks and
+// limited-quirks mode from a DOCTYPE token
+func renderingMode(name, publicID string, hasPublic bool, systemID string, hasSystem bool) string {
+	public := strings.ToLower(publicID)
+	system := strings.ToLower(systemID)
+
+	if name != "html" {
+		return renderingQuirks
+	}
+	if hasPublic {
+		for _, id := range quirksPublicIDs {
+			if public == id {
+				return renderingQuirks
+			}
+		}
+		for _, prefix := range quirksPublicIDPrefixes {
+			if strings.HasPrefix(public, prefix) {
+				return renderingQuirks
+			}
+		}
+	}
+	if system == "http://www.ibm.com/data/dtd/v11/ibmxhtml1-transitional.dtd" {
+		return renderingQuirks
+	}
+
+	html401Loose := strings.HasPrefix(public, "-//w3c//dtd html 4.01 frameset//") ||
+		strings.HasPrefix(public, "-//w3c//dtd html 4.01 transitional//")
+	if html401Loose && !hasSystem {
+		return renderingQuirks
+	}
+	if html401Loose ||
+		strings.HasPrefix(public, "-//w3c//dtd xhtml 1.0 frameset//") ||
+		strings.HasPrefix(public, "-//w3c//dtd xhtml 1.0 transitional//") {
+		return renderingAlmostStandards
+	}
+
+	return renderingStandards
+}
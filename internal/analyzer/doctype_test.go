@@ -0,0 +1,73 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestAnalyzeDoctype tests version, variant and rendering mode detection
+func TestAnalyzeDoctype(t *testing.T) {
+	testCases := []struct {
+		name        string
+		doctype     string
+		contentType string
+		wantVersion string
+		wantVariant string
+		wantKnown   bool
+		wantMode    string
+	}{
+		{"HTML5", `<!DOCTYPE html>`, "", "HTML5", "", true, "standards"},
+		{"HTML5 legacy compat", `<!DOCTYPE html SYSTEM "about:legacy-compat">`, "", "HTML5", "", true, "standards"},
+		{"HTML 2.0", `<!DOCTYPE html PUBLIC "-//IETF//DTD HTML 2.0//EN">`, "", "HTML 2.0", "", true, "quirks"},
+		{"HTML 3.2", `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 3.2 Final//EN">`, "", "HTML 3.2", "", true, "quirks"},
+		{"HTML 4.0 Transitional", `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.0 Transitional//EN" "http://www.w3.org/TR/REC-html40/loose.dtd">`, "", "HTML 4.0", "Transitional", true, "quirks"},
+		{"HTML 4.01 Strict", `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd">`, "", "HTML 4.01", "Strict", true, "standards"},
+		{"HTML 4.01 Transitional", `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01 Transitional//EN" "http://www.w3.org/TR/html4/loose.dtd">`, "", "HTML 4.01", "Transitional", true, "almost-standards"},
+		{"HTML 4.01 Transitional without system ID", `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01 Transitional//EN">`, "", "HTML 4.01", "Transitional", true, "quirks"},
+		{"HTML 4.01 Frameset", `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01 Frameset//EN" "http://www.w3.org/TR/html4/frameset.dtd">`, "", "HTML 4.01", "Frameset", true, "almost-standards"},
+		{"XHTML 1.0 Strict", `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Strict//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-strict.dtd">`, "", "XHTML 1.0", "Strict", true, "standards"},
+		{"XHTML 1.0 Transitional", `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">`, "", "XHTML 1.0", "Transitional", true, "almost-standards"},
+		{"XHTML Basic 1.1", `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML Basic 1.1//EN" "http://www.w3.org/TR/xhtml-basic/xhtml-basic11.dtd">`, "", "XHTML Basic 1.1", "Basic", true, "standards"},
+		{"XHTML plus MathML and SVG", `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.1 plus MathML 2.0 plus SVG 1.1//EN" "http://www.w3.org/2002/04/xhtml-math-svg/xhtml-math-svg.dtd">`, "", "XHTML 1.1 plus MathML 2.0 plus SVG 1.1", "MathML+SVG", true, "standards"},
+		{"System ID only", `<!DOCTYPE html SYSTEM "http://www.w3.org/TR/xhtml11/DTD/xhtml11.dtd">`, "", "XHTML 1.1", "", true, "standards"},
+		{"Quirky vendor DOCTYPE", `<!DOCTYPE HTML PUBLIC "-//Netscape Comm. Corp.//DTD HTML//EN">`, "", "Unknown DOCTYPE", "", false, "quirks"},
+		{"IBM system ID", `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Strict//EN" "http://www.ibm.com/data/dtd/v11/ibmxhtml1-transitional.dtd">`, "", "XHTML 1.0", "Strict", true, "quirks"},
+		{"Non-html name", `<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">`, "", "SVG 1.1", "SVG", true, "quirks"},
+		{"Served as XHTML", `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">`, "application/xhtml+xml; charset=utf-8", "XHTML 1.0", "Transitional", true, "standards"},
+		{"No DOCTYPE", ``, "text/html", "Unknown (No DOCTYPE)", "", false, "quirks"},
+		{"No DOCTYPE served as XHTML", ``, "application/xhtml+xml", "Unknown (No DOCTYPE)", "", false, "standards"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tc.doctype + `<html><head><title>Test</title></head><body></body></html>`))
+			require.NoError(t, err)
+
+			info := analyzeDoctype(doc, tc.contentType)
+
+			assert.Equal(t, tc.doctype != "", info.Present)
+			assert.Equal(t, tc.wantVersion, info.Version)
+			assert.Equal(t, tc.wantVariant, info.Variant)
+			assert.Equal(t, tc.wantKnown, info.Known)
+			assert.Equal(t, tc.wantMode, info.RenderingMode)
+			assert.Equal(t, strings.HasPrefix(tc.contentType, "application/xhtml+xml"), info.ServedAsXHTML)
+		})
+	}
+}
+
+// TestAnalyzeDoctypeIdentifiers tests that both identifiers are reported as written
+func TestAnalyzeDoctypeIdentifiers(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 4.01//EN" "http://www.w3.org/TR/html4/strict.dtd"><html></html>`))
+	require.NoError(t, err)
+
+	info := analyzeDoctype(doc, "text/html; charset=ISO-8859-1")
+
+	assert.Equal(t, "html", info.Name)
+	assert.Equal(t, "-//W3C//DTD HTML 4.01//EN", info.PublicID)
+	assert.Equal(t, "http://www.w3.org/TR/html4/strict.dtd", info.SystemID)
+	assert.Equal(t, "text/html", info.ContentType)
+}
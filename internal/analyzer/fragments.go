@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// collectAnchors returns the fragment identifiers a document can scroll to:
+// the id of any element and the name of <a> elements
+func collectAnchors(doc *html.Node) map[string]bool {
+	anchors := make(map[string]bool)
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if id := getAttr(n, "id"); id != "" {
+				anchors[id] = true
+			}
+			if name := getAttr(n, "name"); name != "" && n.Data == "a" {
+				anchors[name] = true
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	if doc != nil {
+		crawler(doc)
+	}
+	return anchors
+}
+
+// isCheckableFragment reports whether a fragment should match an anchor.
+// The empty fragment and "top" always scroll to the top, and fragments
+// starting with / or ! are client-side routes rather than anchors.
+func isCheckableFragment(fragment string) bool {
+	if fragment == "" || strings.EqualFold(fragment, "top") {
+		return false
+	}
+	return !strings.HasPrefix(fragment, "/") && !strings.HasPrefix(fragment, "!")
+}
+
+// hasAnchor looks a fragment up as the browser does: as written first, then
+// percent-decoded
+func hasAnchor(anchors map[string]bool, u *url.URL) bool {
+	return anchors[u.EscapedFragment()] || anchors[u.Fragment]
+}
+
+// anchorPage is a linked page fetched once for all the fragments pointing to it
+type anchorPage struct {
+	once       sync.Once
+	statusCode int
+	// anchors is nil when the page is not HTML and its fragments can't be checked
+	anchors map[string]bool
+	err     error
+}
+
+// anchorCache fetches the pages targeted by cross-page fragment links
+type anchorCache struct {
+	client *http.Client
+	mu     sync.Mutex
+	pages  map[string]*anchorPage
+}
+
+func newAnchorCache(client *http.Client) *anchorCache {
+	return &anchorCache{client: client, pages: make(map[string]*anchorPage)}
+}
+
+// page returns the fetched page at u, ignoring its fragment
+func (c *anchorCache) page(u *url.URL) *anchorPage {
+	key := comparableURL(u)
+	c.mu.Lock()
+	page, ok := c.pages[key]
+	if !ok {
+		page = &anchorPage{}
+		c.pages[key] = page
+	}
+	c.mu.Unlock()
+
+	page.once.Do(func() {
+		target := *u
+		target.Fragment = ""
+		target.RawFragment = ""
+		page.statusCode, page.anchors, page.err = fetchAnchors(target.String(), c.client)
+	})
+	return page
+}
+
+func fetchAnchors(link string, client *http.Client) (int, map[string]bool, error) {
+	resp, err := client.Get(link)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	if !isAccessibleStatus(resp.StatusCode) {
+		return resp.StatusCode, nil, nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "" && mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return resp.StatusCode, nil, nil
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxResourceBytes))
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("failed to parse %s: %w", link, err)
+	}
+	return resp.StatusCode, collectAnchors(doc), nil
+}
@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestCollectAnchors tests which identifiers a fragment can target
+func TestCollectAnchors(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`
+		<h2 id="intro">Intro</h2>
+		<a name="legacy"></a>
+		<div name="not-an-anchor"></div>
+		<section id="caf%C3%A9"></section>
+	`))
+	require.NoError(t, err)
+
+	anchors := collectAnchors(doc)
+
+	assert.Equal(t, map[string]bool{"intro": true, "legacy": true, "caf%C3%A9": true}, anchors)
+}
+
+// TestIsCheckableFragment tests which fragments are expected to match an anchor
+func TestIsCheckableFragment(t *testing.T) {
+	assert.True(t, isCheckableFragment("install"))
+	assert.False(t, isCheckableFragment(""))
+	assert.False(t, isCheckableFragment("top"))
+	assert.False(t, isCheckableFragment("/settings/profile"))
+	assert.False(t, isCheckableFragment("!/inbox"))
+}
+
+// TestAnalyzeLinksFragments tests same-page and cross-page fragment validation
+func TestAnalyzeLinksFragments(t *testing.T) {
+	var docsRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docs":
+			docsRequests.Add(1)
+			fmt.Fprint(w, `<html><body><h2 id="install">Install</h2></body></html>`)
+		case "/manual.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			fmt.Fprint(w, "%PDF-1.7")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	doc, err := html.Parse(strings.NewReader(`
+		<html><body>
+			<h2 id="usage">Usage</h2>
+			<a name="faq"></a>
+			<a href="#usage">Usage</a>
+			<a href="#faq">FAQ</a>
+			<a href="#missing">Missing</a>
+			<a href="#top">Top</a>
+			<a href="#/route">Route</a>
+			<a href="/guide?x=1#usage">Same page with query</a>
+			<a href="/docs#install">Install</a>
+			<a href="/docs#configure">Configure</a>
+			<a href="/manual.pdf#page=2">Manual</a>
+			<a href="/gone#intro">Gone</a>
+		</body></html>
+	`))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse(server.URL + "/guide?x=1")
+
+	result := analyzeLinks(doc, baseURL, server.Client(), LinkPolicySameSite, false)
+
+	assert.Equal(t, 8, result.FragmentLinks)
+	assert.Equal(t, 3, result.Inaccessible)
+	assert.Equal(t, int32(1), docsRequests.Load())
+
+	require.Len(t, result.DanglingFragments, 2)
+	configure := result.DanglingFragments[0]
+	assert.Equal(t, "/docs#configure", configure.Href)
+	assert.Equal(t, server.URL+"/docs#configure", configure.URL)
+	assert.Equal(t, "configure", configure.Fragment)
+	assert.False(t, configure.SamePage)
+
+	missing := result.DanglingFragments[1]
+	assert.Equal(t, "#missing", missing.Href)
+	assert.True(t, missing.SamePage)
+}
@@ -0,0 +1,438 @@
+package analyzer
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// maxHreflangAlternates caps the alternates fetched for the reciprocity check
+const maxHreflangAlternates = 50
+
+// Where a canonical or alternate was declared
+const (
+	sourceHTML   = "html"
+	sourceHeader = "header"
+)
+
+// languageCodes are the ISO 639-1 codes search engines accept in hreflang
+var languageCodes = toSet(strings.Fields(`
+	aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch co
+	cr cs cu cv cy da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy ga gd
+	gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it iu ja jv
+	ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg
+	mh mi mk ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny oc oj om or os
+	pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq sr ss
+	st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo
+	wa wo xh yi yo za zh zu`)...)
+
+// regionCodes are the ISO 3166-1 alpha-2 codes search engines accept in hreflang
+var regionCodes = toSet(strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ
+	BL BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR
+	CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR
+	GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU
+	ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ
+	LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ
+	MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF
+	PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI
+	SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR
+	TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`)...)
+
+// regionCorrections maps region codes that are often used but are not ISO
+// 3166-1 to the code meant
+var regionCorrections = map[string]string{
+	"UK": "GB",
+}
+
+// linkValue is one link-value of an HTTP Link header
+type linkValue struct {
+	target string
+	params map[string]string
+}
+
+// declaredLink is a canonical or alternate link and where it was declared
+type declaredLink struct {
+	href     string
+	hreflang string
+	source   string
+}
+
+// analyzeCanonical validates the canonical URL and the hreflang alternates
+// declared in the markup and in Link headers. Targets are requested without
+// following redirects; alternates are fetched to check they link back.
+func analyzeCanonical(doc *html.Node, pageURL *url.URL, header http.Header, client *http.Client) models.CanonicalReport {
+	report := models.CanonicalReport{
+		Canonicals: []models.CanonicalCheck{},
+		Alternates: []models.HreflangAlternate{},
+		Issues:     []string{},
+	}
+
+	canonicals, alternates := collectCanonicalLinks(doc, header)
+
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	// The page and its declared canonicals all count as the page when
+	// checking that alternates link back
+	targets := []string{comparableURL(pageURL)}
+	canonicalURLs := make(map[string]bool)
+	for _, link := range canonicals {
+		check := models.CanonicalCheck{
+			URL:    strings.TrimSpace(link.href),
+			Source: link.source,
+			Issues: []string{},
+		}
+		u, err := pageURL.Parse(check.URL)
+		if err != nil || check.URL == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			check.Issues = append(check.Issues, "canonical URL is invalid")
+			report.Canonicals = append(report.Canonicals, check)
+			continue
+		}
+		u.Fragment = ""
+		check.URL = u.String()
+		check.SelfReferencing = comparableURL(u) == comparableURL(pageURL)
+		check.CrossDomain = registrableDomain(u.Hostname()) != registrableDomain(pageURL.Hostname())
+		if check.CrossDomain {
+			check.Issues = append(check.Issues, "canonical points to another domain "+u.Hostname())
+		}
+		canonicalURLs[comparableURL(u)] = true
+		if !containsString(targets, comparableURL(u)) {
+			targets = append(targets, comparableURL(u))
+		}
+		report.Canonicals = append(report.Canonicals, check)
+	}
+
+	if len(canonicalURLs) > 1 {
+		report.Issues = append(report.Issues, fmt.Sprintf("%d different canonical URLs; search engines may ignore all of them", len(canonicalURLs)))
+	}
+	canonicalizedElsewhere := len(canonicalURLs) > 0 && !canonicalURLs[comparableURL(pageURL)]
+
+	hreflangURLs := make(map[string]string)
+	selfReferenced := false
+	for _, link := range alternates {
+		alternate := models.HreflangAlternate{
+			Hreflang: strings.TrimSpace(link.hreflang),
+			URL:      strings.TrimSpace(link.href),
+			Source:   link.source,
+			Issues:   []string{},
+		}
+		var codeIssues []string
+		alternate.ValidCode, codeIssues = validateHreflang(alternate.Hreflang)
+		alternate.Issues = append(alternate.Issues, codeIssues...)
+		if strings.EqualFold(alternate.Hreflang, "x-default") {
+			report.HasXDefault = true
+		}
+
+		u, err := pageURL.Parse(alternate.URL)
+		if err != nil || alternate.URL == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			alternate.Issues = append(alternate.Issues, "alternate URL is invalid")
+			report.Alternates = append(report.Alternates, alternate)
+			continue
+		}
+		u.Fragment = ""
+		alternate.URL = u.String()
+
+		key := strings.ToLower(alternate.Hreflang)
+		if previous, ok := hreflangURLs[key]; ok && previous != comparableURL(u) {
+			report.Issues = append(report.Issues, fmt.Sprintf("hreflang %q points to more than one URL", alternate.Hreflang))
+		}
+		hreflangURLs[key] = comparableURL(u)
+		if containsString(targets, comparableURL(u)) {
+			selfReferenced = true
+		}
+		report.Alternates = append(report.Alternates, alternate)
+	}
+
+	if len(report.Alternates) > 0 {
+		if !selfReferenced {
+			report.Issues = append(report.Issues, "hreflang alternates do not include the page itself")
+		}
+		if !report.HasXDefault {
+			report.Issues = append(report.Issues, "no x-default alternate for unmatched languages")
+		}
+		if canonicalizedElsewhere {
+			report.Issues = append(report.Issues, "hreflang on a page canonicalized to another URL is ignored")
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range report.Canonicals {
+		check := &report.Canonicals[i]
+		u, err := url.Parse(check.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || check.SelfReferencing {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCanonicalTarget(check, &noRedirect)
+		}()
+	}
+
+	fetched := 0
+	for i := range report.Alternates {
+		alternate := &report.Alternates[i]
+		u, err := url.Parse(alternate.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		if containsString(targets, comparableURL(u)) {
+			// The page links to itself
+			alternate.StatusCode = http.StatusOK
+			reciprocal := true
+			alternate.Reciprocal = &reciprocal
+			continue
+		}
+		if fetched == maxHreflangAlternates {
+			report.Issues = append(report.Issues, fmt.Sprintf("only the first %d alternates were checked", maxHreflangAlternates))
+			break
+		}
+		fetched++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkAlternateTarget(alternate, targets, &noRedirect)
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// collectCanonicalLinks returns the canonical and hreflang alternate links of
+// the document, followed by those of the Link headers
+func collectCanonicalLinks(doc *html.Node, header http.Header) ([]declaredLink, []declaredLink) {
+	var canonicals, alternates []declaredLink
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "link" {
+			for _, rel := range strings.Fields(strings.ToLower(getAttr(n, "rel"))) {
+				if rel == "canonical" {
+					canonicals = append(canonicals, declaredLink{href: getAttr(n, "href"), source: sourceHTML})
+				}
+				if hreflang, ok := getAttrOK(n, "hreflang"); ok && rel == "alternate" {
+					alternates = append(alternates, declaredLink{href: getAttr(n, "href"), hreflang: hreflang, source: sourceHTML})
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	if doc != nil {
+		crawler(doc)
+	}
+
+	for _, value := range header.Values("Link") {
+		for _, link := range parseLinkHeader(value) {
+			for _, rel := range strings.Fields(strings.ToLower(link.params["rel"])) {
+				if rel == "canonical" {
+					canonicals = append(canonicals, declaredLink{href: link.target, source: sourceHeader})
+				}
+				if hreflang, ok := link.params["hreflang"]; ok && rel == "alternate" {
+					alternates = append(alternates, declaredLink{href: link.target, hreflang: hreflang, source: sourceHeader})
+				}
+			}
+		}
+	}
+
+	return canonicals, alternates
+}
+
+// parseLinkHeader splits an HTTP Link header (RFC 8288) into its link-values.
+// Commas inside the target or a quoted parameter do not separate values.
+func parseLinkHeader(value string) []linkValue {
+	var links []linkValue
+	for len(value) > 0 {
+		start := strings.IndexByte(value, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(value[start:], '>')
+		if end < 0 {
+			break
+		}
+		link := linkValue{
+			target: strings.TrimSpace(value[start+1 : start+end]),
+			params: make(map[string]string),
+		}
+		value = value[start+end+1:]
+
+		// Parameters run until a comma outside quotes
+		for {
+			value = strings.TrimLeft(value, " \t")
+			if !strings.HasPrefix(value, ";") {
+				break
+			}
+			value = strings.TrimLeft(value[1:], " \t")
+			nameEnd := strings.IndexAny(value, "=;,")
+			if nameEnd < 0 {
+				nameEnd = len(value)
+			}
+			name := strings.ToLower(strings.TrimSpace(value[:nameEnd]))
+			value = value[nameEnd:]
+
+			var param string
+			if strings.HasPrefix(value, "=") {
+				value = strings.TrimLeft(value[1:], " \t")
+				if strings.HasPrefix(value, `"`) {
+					closing := strings.IndexByte(value[1:], '"')
+					if closing < 0 {
+						closing = len(value) - 1
+					}
+					param = value[1 : closing+1]
+					value = value[min(closing+2, len(value)):]
+				} else {
+					paramEnd := strings.IndexAny(value, ";,")
+					if paramEnd < 0 {
+						paramEnd = len(value)
+					}
+					param = strings.TrimSpace(value[:paramEnd])
+					value = value[paramEnd:]
+				}
+			}
+			// Only the first occurrence of a parameter counts
+			if _, ok := link.params[name]; !ok && name != "" {
+				link.params[name] = param
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// validateHreflang checks an hreflang value: x-default, or an ISO 639-1
+// language optionally followed by a script and an ISO 3166-1 region
+func validateHreflang(code string) (bool, []string) {
+	if strings.EqualFold(code, "x-default") {
+		return true, nil
+	}
+	if code == "" {
+		return false, []string{"empty hreflang"}
+	}
+	if strings.Contains(code, "_") {
+		return false, []string{fmt.Sprintf("hreflang %q uses an underscore; use a hyphen", code)}
+	}
+
+	parts := strings.Split(code, "-")
+	language := strings.ToLower(parts[0])
+	if !languageCodes[language] {
+		if regionCodes[strings.ToUpper(language)] && len(parts) == 1 {
+			return false, []string{fmt.Sprintf("hreflang %q is a region; a language is required", code)}
+		}
+		return false, []string{fmt.Sprintf("hreflang %q does not start with an ISO 639-1 language code", code)}
+	}
+	parts = parts[1:]
+
+	// An optional four-letter script subtag, e.g. zh-Hant
+	if len(parts) > 0 && len(parts[0]) == 4 && isLetters(parts[0]) {
+		parts = parts[1:]
+	}
+	if len(parts) == 0 {
+		return true, nil
+	}
+	if len(parts) > 1 {
+		return false, []string{fmt.Sprintf("hreflang %q has too many subtags", code)}
+	}
+
+	region := strings.ToUpper(parts[0])
+	if meant, ok := regionCorrections[region]; ok {
+		return false, []string{fmt.Sprintf("hreflang %q uses region %s; use %s", code, parts[0], meant)}
+	}
+	if !regionCodes[region] {
+		return false, []string{fmt.Sprintf("hreflang %q has an unknown ISO 3166-1 region", code)}
+	}
+	return true, nil
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// checkCanonicalTarget records the status of a canonical URL; a canonical
+// must answer 200 itself rather than redirect
+func checkCanonicalTarget(check *models.CanonicalCheck, client *http.Client) {
+	resp, err := client.Get(check.URL)
+	if err != nil {
+		check.Error = err.Error()
+		check.Issues = append(check.Issues, "canonical URL could not be fetched")
+		return
+	}
+	resp.Body.Close()
+
+	check.StatusCode = resp.StatusCode
+	switch {
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		if location, err := resp.Location(); err == nil {
+			check.RedirectsTo = location.String()
+		}
+		check.Issues = append(check.Issues, fmt.Sprintf("canonical redirects (%d)", resp.StatusCode))
+	case resp.StatusCode != http.StatusOK:
+		check.Issues = append(check.Issues, fmt.Sprintf("canonical returns %d", resp.StatusCode))
+	}
+}
+
+// checkAlternateTarget fetches an alternate and checks that it answers 200
+// and declares an alternate pointing back to one of targets
+func checkAlternateTarget(alternate *models.HreflangAlternate, targets []string, client *http.Client) {
+	resp, err := client.Get(alternate.URL)
+	if err != nil {
+		alternate.Error = err.Error()
+		alternate.Issues = append(alternate.Issues, "alternate could not be fetched")
+		return
+	}
+	defer resp.Body.Close()
+
+	alternate.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			alternate.Issues = append(alternate.Issues, fmt.Sprintf("alternate redirects (%d)", resp.StatusCode))
+		} else {
+			alternate.Issues = append(alternate.Issues, fmt.Sprintf("alternate returns %d", resp.StatusCode))
+		}
+		return
+	}
+
+	doc, err := html.Parse(io.LimitReader(resp.Body, maxResourceBytes))
+	if err != nil {
+		alternate.Error = err.Error()
+		return
+	}
+	alternateURL := resp.Request.URL
+	_, links := collectCanonicalLinks(doc, resp.Header)
+
+	reciprocal := false
+	for _, link := range links {
+		u, err := alternateURL.Parse(strings.TrimSpace(link.href))
+		if err == nil && containsString(targets, comparableURL(u)) {
+			reciprocal = true
+			break
+		}
+	}
+	alternate.Reciprocal = &reciprocal
+	if !reciprocal {
+		alternate.Issues = append(alternate.Issues, "alternate does not link back to this page")
+	}
+}
+
+// comparableURL reduces a URL to the form used to decide whether two links
+// point to the same page
+func comparableURL(u *url.URL) string {
+	return pageNormalizer.NormalizeURL(u).String()
+}
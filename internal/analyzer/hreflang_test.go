@@ -0,0 +1,189 @@
+package analyzer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestParseLinkHeader tests splitting Link headers into link-values
+func TestParseLinkHeader(t *testing.T) {
+	links := parseLinkHeader(`<https://example.com/a,b>; rel="alternate"; hreflang="de", <https://example.com/>; rel=canonical, <https://example.com/x>; title="one, two"; rel="alternate preload"`)
+
+	require.Len(t, links, 3)
+	assert.Equal(t, "https://example.com/a,b", links[0].target)
+	assert.Equal(t, map[string]string{"rel": "alternate", "hreflang": "de"}, links[0].params)
+	assert.Equal(t, "https://example.com/", links[1].target)
+	assert.Equal(t, "canonical", links[1].params["rel"])
+	assert.Equal(t, "one, two", links[2].params["title"])
+	assert.Equal(t, "alternate preload", links[2].params["rel"])
+
+	assert.Empty(t, parseLinkHeader(""))
+	assert.Empty(t, parseLinkHeader("rel=canonical"))
+}
+
+// TestValidateHreflang tests language and region code validation
+func TestValidateHreflang(t *testing.T) {
+	testCases := []struct {
+		code  string
+		valid bool
+	}{
+		{"en", true},
+		{"en-US", true},
+		{"en-us", true},
+		{"x-default", true},
+		{"X-Default", true},
+		{"zh-Hant", true},
+		{"zh-Hant-TW", true},
+		{"en-UK", false},
+		{"en_US", false},
+		{"us", false},
+		{"eng", false},
+		{"en-XX", false},
+		{"en-US-CA", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			valid, issues := validateHreflang(tc.code)
+			assert.Equal(t, tc.valid, valid)
+			if tc.valid {
+				assert.Empty(t, issues)
+			} else {
+				assert.Len(t, issues, 1)
+			}
+		})
+	}
+
+	_, issues := validateHreflang("en-UK")
+	assert.Contains(t, issues[0], "use GB")
+}
+
+// TestAnalyzeCanonical tests canonical status checks and hreflang reciprocity
+func TestAnalyzeCanonical(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/en/", http.StatusMovedPermanently)
+		case "/de/":
+			fmt.Fprintf(w, `<link rel="alternate" hreflang="en" href="%s/en/">`, server.URL)
+		case "/fr/":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/en/>; rel="alternate"; hreflang="en"`, server.URL))
+			fmt.Fprint(w, `<p>Bonjour</p>`)
+		case "/es/":
+			fmt.Fprint(w, `<link rel="alternate" hreflang="es" href="/es/">`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	pageURL, _ := url.Parse(server.URL + "/en/")
+
+	t.Run("Reciprocal alternates", func(t *testing.T) {
+		doc, err := html.Parse(strings.NewReader(`
+			<html><head>
+				<link rel="canonical" href="/en/">
+				<link rel="alternate" hreflang="en" href="/en/">
+				<link rel="alternate" hreflang="de" href="/de/">
+				<link rel="alternate" hreflang="es" href="/es/">
+				<link rel="alternate" hreflang="en-UK" href="/gone/">
+			</head><body></body></html>`))
+		require.NoError(t, err)
+		header := http.Header{}
+		header.Add("Link", `</fr/>; rel="alternate"; hreflang="fr"`)
+
+		report := analyzeCanonical(doc, pageURL, header, server.Client())
+
+		require.Len(t, report.Canonicals, 1)
+		assert.True(t, report.Canonicals[0].SelfReferencing)
+		assert.Empty(t, report.Canonicals[0].Issues)
+
+		require.Len(t, report.Alternates, 5)
+		alternates := make(map[string]int)
+		for i, alternate := range report.Alternates {
+			alternates[alternate.Hreflang] = i
+		}
+
+		self := report.Alternates[alternates["en"]]
+		require.NotNil(t, self.Reciprocal)
+		assert.True(t, *self.Reciprocal)
+
+		de := report.Alternates[alternates["de"]]
+		assert.Equal(t, http.StatusOK, de.StatusCode)
+		require.NotNil(t, de.Reciprocal)
+		assert.True(t, *de.Reciprocal)
+
+		fr := report.Alternates[alternates["fr"]]
+		assert.Equal(t, sourceHeader, fr.Source)
+		require.NotNil(t, fr.Reciprocal)
+		assert.True(t, *fr.Reciprocal)
+
+		es := report.Alternates[alternates["es"]]
+		require.NotNil(t, es.Reciprocal)
+		assert.False(t, *es.Reciprocal)
+		assert.Contains(t, es.Issues, "alternate does not link back to this page")
+
+		uk := report.Alternates[alternates["en-UK"]]
+		assert.False(t, uk.ValidCode)
+		assert.Equal(t, http.StatusNotFound, uk.StatusCode)
+		assert.Nil(t, uk.Reciprocal)
+		assert.Len(t, uk.Issues, 2)
+
+		assert.False(t, report.HasXDefault)
+		assert.Equal(t, []string{"no x-default alternate for unmatched languages"}, report.Issues)
+	})
+
+	t.Run("Canonical problems", func(t *testing.T) {
+		doc, err := html.Parse(strings.NewReader(`
+			<html><head>
+				<link rel="canonical" href="/moved">
+				<link rel="alternate" hreflang="x-default" href="/de/">
+			</head><body></body></html>`))
+		require.NoError(t, err)
+		header := http.Header{}
+		// The same server under another host name is another domain
+		otherDomain := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+		header.Add("Link", "<"+otherDomain+`/de/>; rel="canonical"`)
+
+		report := analyzeCanonical(doc, pageURL, header, server.Client())
+
+		require.Len(t, report.Canonicals, 2)
+		moved := report.Canonicals[0]
+		assert.Equal(t, sourceHTML, moved.Source)
+		assert.Equal(t, http.StatusMovedPermanently, moved.StatusCode)
+		assert.Equal(t, server.URL+"/en/", moved.RedirectsTo)
+		assert.False(t, moved.CrossDomain)
+
+		other := report.Canonicals[1]
+		assert.Equal(t, sourceHeader, other.Source)
+		assert.True(t, other.CrossDomain)
+		assert.Equal(t, http.StatusOK, other.StatusCode)
+		assert.Len(t, other.Issues, 1)
+
+		assert.True(t, report.HasXDefault)
+		assert.Contains(t, report.Issues, "2 different canonical URLs; search engines may ignore all of them")
+		assert.Contains(t, report.Issues, "hreflang alternates do not include the page itself")
+		assert.Contains(t, report.Issues, "hreflang on a page canonicalized to another URL is ignored")
+	})
+
+	t.Run("No declarations", func(t *testing.T) {
+		doc, err := html.Parse(strings.NewReader(`<html><head></head><body></body></html>`))
+		require.NoError(t, err)
+
+		report := analyzeCanonical(doc, pageURL, http.Header{}, server.Client())
+
+		assert.Empty(t, report.Canonicals)
+		assert.Empty(t, report.Alternates)
+		assert.Empty(t, report.Issues)
+	})
+}
@@ -0,0 +1,235 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// sriAlgorithms lists the hash algorithms browsers accept in an integrity
+// attribute, weakest first
+var sriAlgorithms = []struct {
+	name string
+	size int
+	hash func() hash.Hash
+}{
+	{"sha256", sha256.Size, sha256.New},
+	{"sha384", sha512.Size384, sha512.New384},
+	{"sha512", sha512.Size, sha512.New},
+}
+
+// auditIntegrity reports on the integrity and crossorigin attributes of every
+// script and stylesheet of the resource inventory loaded from another origin
+// than the page
+func auditIntegrity(baseURL *url.URL, refs []resourceRef) models.IntegrityReport {
+	report := models.IntegrityReport{
+		UnprotectedThirdParty: []string{},
+		Checks:                []models.IntegrityCheck{},
+	}
+	seen := make(map[string]bool)
+
+	for _, ref := range refs {
+		if ref.Type != resourceScript && ref.Type != resourceStylesheet {
+			continue
+		}
+		u, err := url.Parse(ref.URL)
+		if err != nil || sameOrigin(u, baseURL) || seen[ref.URL] {
+			continue
+		}
+		seen[ref.URL] = true
+
+		n := ref.node
+		check := models.IntegrityCheck{
+			URL:        ref.URL,
+			Element:    ref.Element,
+			Host:       ref.Host,
+			ThirdParty: ref.ThirdParty,
+			Algorithms: []string{},
+			Issues:     []string{},
+		}
+
+		integrity, hasIntegrity := getAttrOK(n, "integrity")
+		integrity = strings.TrimSpace(integrity)
+		check.Integrity = integrity
+		check.HasIntegrity = hasIntegrity && integrity != ""
+		if check.HasIntegrity {
+			algorithms, issues := parseIntegrity(integrity)
+			check.Algorithms = algorithms
+			check.Issues = append(check.Issues, issues...)
+			check.ValidFormat = len(algorithms) > 0
+			if !check.ValidFormat {
+				check.Issues = append(check.Issues, "integrity has no valid hash; the browser ignores it")
+			}
+		} else {
+			check.Issues = append(check.Issues, "missing integrity attribute")
+		}
+
+		crossOrigin, hasCrossOrigin := getAttrOK(n, "crossorigin")
+		check.CrossOrigin = strings.ToLower(strings.TrimSpace(crossOrigin))
+		switch {
+		case !hasCrossOrigin:
+			if check.HasIntegrity {
+				check.Issues = append(check.Issues, "integrity on a cross-origin resource requires crossorigin; the browser blocks it")
+			}
+		case check.CrossOrigin == "" || check.CrossOrigin == "anonymous":
+			check.CrossOrigin = "anonymous"
+			check.CrossOriginValid = true
+		case check.CrossOrigin == "use-credentials":
+			check.CrossOriginValid = true
+			if check.ThirdParty {
+				check.Issues = append(check.Issues, "crossorigin=use-credentials sends cookies to a third party")
+			}
+		default:
+			check.Issues = append(check.Issues, fmt.Sprintf("invalid crossorigin value %q", crossOrigin))
+		}
+
+		if check.HasIntegrity {
+			report.WithIntegrity++
+		} else {
+			report.WithoutIntegrity++
+		}
+		if check.ThirdParty && !check.ValidFormat {
+			report.UnprotectedThirdParty = append(report.UnprotectedThirdParty, check.URL)
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	report.Total = len(report.Checks)
+	return report
+}
+
+// parseIntegrity returns the algorithms of the well-formed hashes in an
+// integrity attribute, and an issue for every malformed one
+func parseIntegrity(integrity string) ([]string, []string) {
+	algorithms := []string{}
+	var issues []string
+	for _, token := range strings.Fields(integrity) {
+		algorithm, digest, ok := strings.Cut(token, "-")
+		digest, _, _ = strings.Cut(digest, "?")
+		if !ok {
+			issues = append(issues, fmt.Sprintf("malformed integrity token %q", token))
+			continue
+		}
+
+		size := 0
+		for _, a := range sriAlgorithms {
+			if strings.EqualFold(a.name, algorithm) {
+				size = a.size
+			}
+		}
+		if size == 0 {
+			issues = append(issues, fmt.Sprintf("unsupported integrity algorithm %q", algorithm))
+			continue
+		}
+		if decoded, err := decodeDigest(digest); err != nil || len(decoded) != size {
+			issues = append(issues, fmt.Sprintf("invalid %s digest", strings.ToLower(algorithm)))
+			continue
+		}
+		if name := strings.ToLower(algorithm); !containsString(algorithms, name) {
+			algorithms = append(algorithms, name)
+		}
+	}
+	return algorithms, issues
+}
+
+// decodeDigest accepts the base64 and base64url encodings browsers accept
+func decodeDigest(digest string) ([]byte, error) {
+	if strings.ContainsAny(digest, "-_") {
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(digest, "="))
+	}
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(digest, "="))
+}
+
+// verifyIntegrity fetches every resource with a well-formed integrity
+// attribute and compares its hash, using the strongest algorithm listed as
+// the browser does
+func verifyIntegrity(checks []models.IntegrityCheck, client *http.Client) {
+	var wg sync.WaitGroup
+	for i := range checks {
+		if !checks[i].ValidFormat {
+			continue
+		}
+		wg.Add(1)
+		go func(check *models.IntegrityCheck) {
+			defer wg.Done()
+
+			verified, err := matchesIntegrity(check.URL, check.Integrity, client)
+			if err != nil {
+				check.VerificationError = err.Error()
+				return
+			}
+			check.Verified = &verified
+			if !verified {
+				check.Issues = append(check.Issues, "content does not match the integrity hash; the browser blocks it")
+			}
+		}(&checks[i])
+	}
+	wg.Wait()
+}
+
+func matchesIntegrity(link, integrity string, client *http.Client) (bool, error) {
+	resp, err := client.Get(link)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	// Only the hashes of the strongest algorithm present take part
+	strongest := -1
+	var expected [][]byte
+	for _, token := range strings.Fields(integrity) {
+		algorithm, digest, _ := strings.Cut(token, "-")
+		digest, _, _ = strings.Cut(digest, "?")
+		decoded, err := decodeDigest(digest)
+		if err != nil {
+			continue
+		}
+		for i, a := range sriAlgorithms {
+			if !strings.EqualFold(a.name, algorithm) || len(decoded) != a.size || i < strongest {
+				continue
+			}
+			if i > strongest {
+				strongest, expected = i, nil
+			}
+			expected = append(expected, decoded)
+		}
+	}
+	if strongest < 0 {
+		return false, fmt.Errorf("no valid integrity hash")
+	}
+
+	h := sriAlgorithms[strongest].hash()
+	size, err := io.Copy(h, io.LimitReader(resp.Body, maxResourceBytes+1))
+	if err != nil {
+		return false, err
+	}
+	if size > maxResourceBytes {
+		return false, fmt.Errorf("too large to verify; over %d MB", maxResourceBytes>>20)
+	}
+	sum := h.Sum(nil)
+	for _, digest := range expected {
+		if string(digest) == string(sum) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// sameOrigin reports whether two URLs share scheme, host and port
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Hostname(), b.Hostname()) &&
+		portOrDefault(a.Port(), a.Scheme) == portOrDefault(b.Port(), b.Scheme)
+}
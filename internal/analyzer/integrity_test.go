@@ -0,0 +1,189 @@
+package analyzer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+func sriHash(algorithm string, content []byte) string {
+	switch algorithm {
+	case "sha256":
+		sum := sha256.Sum256(content)
+		return "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+	case "sha384":
+		sum := sha512.Sum384(content)
+		return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+	sum := sha512.Sum512(content)
+	return "sha512-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// TestParseIntegrity tests integrity attribute validation
+func TestParseIntegrity(t *testing.T) {
+	valid256 := sriHash("sha256", []byte("a"))
+	valid384 := sriHash("sha384", []byte("a"))
+
+	testCases := []struct {
+		name           string
+		integrity      string
+		wantAlgorithms []string
+		wantIssues     int
+	}{
+		{"Single hash", valid384, []string{"sha384"}, 0},
+		{"Multiple algorithms", valid256 + " " + valid384, []string{"sha256", "sha384"}, 0},
+		{"Hash with options", valid384 + "?ct=application/javascript", []string{"sha384"}, 0},
+		{"Unpadded base64url", strings.TrimRight(strings.NewReplacer("+", "-", "/", "_").Replace(valid256), "="), []string{"sha256"}, 0},
+		{"Unsupported algorithm", "md5-1B2M2Y8AsgTpgAmY7PhCfg==", []string{}, 1},
+		{"Wrong digest length", "sha384-" + strings.TrimPrefix(valid256, "sha256-"), []string{}, 1},
+		{"Not base64", "sha256-not*base64", []string{}, 1},
+		{"Missing separator", "sha256", []string{}, 1},
+		{"One of two valid", valid256 + " sha512-abc", []string{"sha256"}, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			algorithms, issues := parseIntegrity(tc.integrity)
+			assert.Equal(t, tc.wantAlgorithms, algorithms)
+			assert.Len(t, issues, tc.wantIssues)
+		})
+	}
+}
+
+// TestAuditIntegrity tests which resources are audited and the crossorigin checks
+func TestAuditIntegrity(t *testing.T) {
+	hash := sriHash("sha384", []byte("lib"))
+	body := fmt.Sprintf(`
+		<html><head>
+			<script src="/js/app.js"></script>
+			<script src="https://www.example.com/js/same-origin.js"></script>
+			<script src="https://static.example.com/js/subdomain.js"></script>
+			<script src="https://cdn.jsdelivr.net/npm/lib.min.js" integrity="%s" crossorigin="anonymous"></script>
+			<script src="https://cdn.other.net/no-cors.js" integrity="%s"></script>
+			<script src="https://cdn.other.net/creds.js" integrity="%s" crossorigin="use-credentials"></script>
+			<link rel="stylesheet" href="https://fonts.example.org/css" integrity="md5-abc" crossorigin>
+			<link rel="preload" as="image" href="https://img.other.net/hero.png">
+			<script>inline()</script>
+		</head><body></body></html>
+	`, hash, hash, hash)
+	doc, err := html.Parse(strings.NewReader(body))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://www.example.com/")
+
+	report := auditIntegrity(baseURL, resourceReferences(doc, baseURL))
+
+	require.Equal(t, 5, report.Total)
+	assert.Equal(t, 4, report.WithIntegrity)
+	assert.Equal(t, 1, report.WithoutIntegrity)
+	assert.Equal(t, []string{"https://fonts.example.org/css"}, report.UnprotectedThirdParty)
+
+	checks := make(map[string]int)
+	for i, check := range report.Checks {
+		checks[check.URL] = i
+	}
+
+	subdomain := report.Checks[checks["https://static.example.com/js/subdomain.js"]]
+	assert.False(t, subdomain.ThirdParty)
+	assert.False(t, subdomain.HasIntegrity)
+	assert.Contains(t, subdomain.Issues, "missing integrity attribute")
+
+	lib := report.Checks[checks["https://cdn.jsdelivr.net/npm/lib.min.js"]]
+	assert.True(t, lib.ValidFormat)
+	assert.Equal(t, []string{"sha384"}, lib.Algorithms)
+	assert.Equal(t, "anonymous", lib.CrossOrigin)
+	assert.True(t, lib.CrossOriginValid)
+	assert.Empty(t, lib.Issues)
+	assert.Nil(t, lib.Verified)
+
+	noCORS := report.Checks[checks["https://cdn.other.net/no-cors.js"]]
+	assert.False(t, noCORS.CrossOriginValid)
+	assert.Len(t, noCORS.Issues, 1)
+
+	creds := report.Checks[checks["https://cdn.other.net/creds.js"]]
+	assert.True(t, creds.CrossOriginValid)
+	assert.Len(t, creds.Issues, 1)
+
+	css := report.Checks[checks["https://fonts.example.org/css"]]
+	assert.Equal(t, "link", css.Element)
+	assert.True(t, css.HasIntegrity)
+	assert.False(t, css.ValidFormat)
+	assert.Equal(t, "anonymous", css.CrossOrigin)
+}
+
+// TestVerifyIntegrity tests hash verification against the fetched content
+func TestVerifyIntegrity(t *testing.T) {
+	content := []byte("console.log('lib');")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.js" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	doc, err := html.Parse(strings.NewReader(fmt.Sprintf(`
+		<script src="%[1]s/match.js" integrity="%[2]s %[3]s" crossorigin="anonymous"></script>
+		<script src="%[1]s/stronger-wins.js" integrity="%[2]s %[4]s" crossorigin="anonymous"></script>
+		<script src="%[1]s/missing.js" integrity="%[2]s" crossorigin="anonymous"></script>
+		<script src="%[1]s/unchecked.js"></script>
+	`, server.URL, sriHash("sha256", content), sriHash("sha512", content), sriHash("sha512", []byte("other")))))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://www.example.com/")
+
+	report := auditIntegrity(baseURL, resourceReferences(doc, baseURL))
+	verifyIntegrity(report.Checks, server.Client())
+
+	results := make(map[string]bool)
+	for _, check := range report.Checks {
+		name := strings.TrimPrefix(check.URL, server.URL+"/")
+		if name == "missing.js" {
+			assert.Nil(t, check.Verified)
+			assert.Contains(t, check.VerificationError, "404")
+			continue
+		}
+		if name == "unchecked.js" {
+			assert.Nil(t, check.Verified)
+			continue
+		}
+		require.NotNil(t, check.Verified, name)
+		results[name] = *check.Verified
+	}
+
+	assert.Equal(t, map[string]bool{"match.js": true, "stronger-wins.js": false}, results)
+}
+
+// TestVerifyIntegrityTooLarge tests that a resource over the download cap is
+// not reported as a mismatch
+func TestVerifyIntegrityTooLarge(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), maxResourceBytes+1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	checks := []models.IntegrityCheck{{
+		URL:         server.URL + "/bundle.js",
+		Integrity:   sriHash("sha384", content),
+		ValidFormat: true,
+		Issues:      []string{},
+	}}
+	verifyIntegrity(checks, server.Client())
+
+	assert.Nil(t, checks[0].Verified)
+	assert.Contains(t, checks[0].VerificationError, "too large to verify")
+	assert.Empty(t, checks[0].Issues)
+}
@@ -0,0 +1,285 @@
+package analyzer
+
+import (
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+const (
+	// maxKeywords is the number of single keywords reported
+	maxKeywords = 20
+	// maxNGrams is the number of phrases reported for each length
+	maxNGrams = 10
+	// introductionWords is how far into the content the target keyword
+	// should appear
+	introductionWords = 100
+)
+
+// Target keyword locations
+const (
+	keywordInTitle        = "title"
+	keywordInDescription  = "meta-description"
+	keywordInH1           = "h1"
+	keywordInHeadings     = "headings"
+	keywordInURL          = "url"
+	keywordInContent      = "content"
+	keywordInIntroduction = "first-100-words"
+	keywordInImageAlt     = "image-alt"
+	keywordInAnchorText   = "anchor-text"
+)
+
+// prominentKeywordLocations are where a target keyword is expected to appear
+var prominentKeywordLocations = []string{
+	keywordInTitle, keywordInDescription, keywordInH1, keywordInURL, keywordInIntroduction,
+}
+
+// keywordPage holds the words of the page parts keywords are looked up in
+type keywordPage struct {
+	title       []string
+	description []string
+	h1s         [][]string
+	headings    [][]string
+	url         []string
+	lines       [][]string
+	imageAlts   [][]string
+	anchorTexts [][]string
+}
+
+// analyzeKeywords reports the most frequent words and phrases of the main
+// content and where they appear, along with the target keyword when one is
+// given
+func analyzeKeywords(doc *html.Node, pageURL *url.URL, content models.ContentReport, target string) models.KeywordReport {
+	language := content.Language
+	if language == "" {
+		language, _, _ = strings.Cut(strings.ToLower(content.DeclaredLanguage), "-")
+	}
+	report := models.KeywordReport{
+		Keywords: []models.Keyword{},
+		Bigrams:  []models.Keyword{},
+		Trigrams: []models.Keyword{},
+	}
+	if _, ok := stopWords[language]; ok {
+		report.Language = language
+	}
+	stop := stopWordsFor(language)
+	page := collectKeywordPage(doc, pageURL, content.Text)
+
+	isKeyword := func(word string) bool {
+		if stop[word] || utf8.RuneCountInString(word) < 2 {
+			return false
+		}
+		return strings.IndexFunc(word, unicode.IsLetter) >= 0
+	}
+
+	words := make(map[string]int)
+	bigrams := make(map[string]int)
+	trigrams := make(map[string]int)
+	for _, line := range page.lines {
+		report.TotalWords += len(line)
+		for _, word := range line {
+			if isKeyword(word) {
+				words[word]++
+			}
+		}
+	}
+	// Phrases do not run across sentences, and neither start nor end with a
+	// stop word
+	countNGrams := func(tokens []string, n int, counts map[string]int) {
+		for i := 0; i+n <= len(tokens); i++ {
+			if gram := tokens[i : i+n]; isKeyword(gram[0]) && isKeyword(gram[n-1]) {
+				counts[strings.Join(gram, " ")]++
+			}
+		}
+	}
+	for _, line := range strings.Split(content.Text, "\n") {
+		for _, sentence := range splitSentences(line) {
+			tokens := lowerWords(sentence)
+			countNGrams(tokens, 2, bigrams)
+			countNGrams(tokens, 3, trigrams)
+		}
+	}
+
+	report.Keywords = topKeywords(words, 1, maxKeywords, report.TotalWords, page)
+	report.Bigrams = topKeywords(bigrams, 2, maxNGrams, report.TotalWords, page)
+	report.Trigrams = topKeywords(trigrams, 2, maxNGrams, report.TotalWords, page)
+
+	if phrase := lowerWords(target); len(phrase) > 0 {
+		report.Target = targetKeyword(strings.Join(phrase, " "), phrase, report.TotalWords, page)
+	}
+
+	return report
+}
+
+// stopWordsFor returns the stop words of a language, or those of every
+// known language when it is unknown
+func stopWordsFor(language string) map[string]bool {
+	if set, ok := stopWords[language]; ok {
+		return set
+	}
+	all := make(map[string]bool)
+	for _, set := range stopWords {
+		for word := range set {
+			all[word] = true
+		}
+	}
+	return all
+}
+
+func collectKeywordPage(doc *html.Node, pageURL *url.URL, text string) keywordPage {
+	page := keywordPage{title: lowerWords(extractTitle(doc))}
+	for _, line := range strings.Split(text, "\n") {
+		if words := lowerWords(line); len(words) > 0 {
+			page.lines = append(page.lines, words)
+		}
+	}
+	if pageURL != nil {
+		page.url = strings.FieldsFunc(strings.ToLower(pageURL.Path), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+	}
+
+	descriptionFound := false
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "meta":
+				if !descriptionFound && strings.EqualFold(getAttr(n, "name"), "description") {
+					page.description = lowerWords(getAttr(n, "content"))
+					descriptionFound = true
+				}
+			case "h1":
+				page.h1s = append(page.h1s, lowerWords(textContent(n)))
+			case "h2", "h3", "h4", "h5", "h6":
+				page.headings = append(page.headings, lowerWords(textContent(n)))
+			case "img":
+				if alt := getAttr(n, "alt"); alt != "" {
+					page.imageAlts = append(page.imageAlts, lowerWords(alt))
+				}
+			case "a":
+				page.anchorTexts = append(page.anchorTexts, lowerWords(textContent(n)))
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+	return page
+}
+
+// topKeywords ranks terms by count, keeping those seen at least minCount
+// times, and records where each appears
+func topKeywords(counts map[string]int, minCount, limit, totalWords int, page keywordPage) []models.Keyword {
+	terms := make([]string, 0, len(counts))
+	for term, count := range counts {
+		if count >= minCount {
+			terms = append(terms, term)
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if counts[terms[i]] != counts[terms[j]] {
+			return counts[terms[i]] > counts[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+
+	keywords := make([]models.Keyword, 0, len(terms))
+	for _, term := range terms {
+		phrase := strings.Fields(term)
+		keywords = append(keywords, models.Keyword{
+			Term:              term,
+			Count:             counts[term],
+			Density:           keywordDensity(counts[term], totalWords),
+			InTitle:           countPhrase(page.title, phrase) > 0,
+			InH1:              countPhraseIn(page.h1s, phrase) > 0,
+			InMetaDescription: countPhrase(page.description, phrase) > 0,
+			InURL:             countPhrase(page.url, phrase) > 0,
+		})
+	}
+	return keywords
+}
+
+func targetKeyword(keyword string, phrase []string, totalWords int, page keywordPage) *models.TargetKeywordReport {
+	var introduction []string
+	for _, line := range page.lines {
+		introduction = append(introduction, line...)
+		if len(introduction) >= introductionWords {
+			introduction = introduction[:introductionWords]
+			break
+		}
+	}
+
+	report := &models.TargetKeywordReport{
+		Keyword: keyword,
+		Count:   countPhraseIn(page.lines, phrase),
+		Occurrences: map[string]int{
+			keywordInTitle:        countPhrase(page.title, phrase),
+			keywordInDescription:  countPhrase(page.description, phrase),
+			keywordInH1:           countPhraseIn(page.h1s, phrase),
+			keywordInHeadings:     countPhraseIn(page.headings, phrase),
+			keywordInURL:          countPhrase(page.url, phrase),
+			keywordInIntroduction: countPhrase(introduction, phrase),
+			keywordInImageAlt:     countPhraseIn(page.imageAlts, phrase),
+			keywordInAnchorText:   countPhraseIn(page.anchorTexts, phrase),
+		},
+		Missing: []string{},
+	}
+	report.Occurrences[keywordInContent] = report.Count
+	report.Density = keywordDensity(report.Count, totalWords)
+	for _, location := range prominentKeywordLocations {
+		if report.Occurrences[location] == 0 {
+			report.Missing = append(report.Missing, location)
+		}
+	}
+	return report
+}
+
+// countPhrase counts the occurrences of the word sequence phrase in words
+func countPhrase(words, phrase []string) int {
+	count := 0
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		match := true
+		for j, word := range phrase {
+			if words[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			count++
+		}
+	}
+	return count
+}
+
+func countPhraseIn(texts [][]string, phrase []string) int {
+	count := 0
+	for _, words := range texts {
+		count += countPhrase(words, phrase)
+	}
+	return count
+}
+
+// keywordDensity is the percentage of the words taken by count occurrences
+func keywordDensity(count, totalWords int) float64 {
+	if totalWords == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(totalWords)*10000) / 100
+}
+
+func lowerWords(text string) []string {
+	return tokenizeWords(strings.ToLower(text))
+}
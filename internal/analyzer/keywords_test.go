@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+const keywordPageHTML = `<html lang="en"><head>
+	<title>Tomato Seeds for Beginners</title>
+	<meta name="description" content="How to sow tomato seeds indoors.">
+</head><body><main>
+	<h1>Sowing tomato seeds</h1>
+	<p>Tomato seeds germinate in a week when the soil is warm. Sow the tomato seeds shallowly.</p>
+	<h2>Caring for seedlings</h2>
+	<p>Seedlings need light. Water the seedlings from below, and keep the soil warm.</p>
+	<img src="seeds.jpg" alt="Tomato seeds in a tray">
+	<a href="/shop">Buy tomato seeds</a>
+</main></body></html>`
+
+func analyzeKeywordPage(t *testing.T, target string) models.KeywordReport {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(keywordPageHTML))
+	require.NoError(t, err)
+	pageURL, _ := url.Parse("https://www.example.com/guides/tomato-seeds")
+
+	content := analyzeContent(doc, []byte(keywordPageHTML), nil)
+	return analyzeKeywords(doc, pageURL, content, target)
+}
+
+// TestAnalyzeKeywords tests keyword and n-gram ranking
+func TestAnalyzeKeywords(t *testing.T) {
+	report := analyzeKeywordPage(t, "")
+
+	assert.Equal(t, "en", report.Language)
+	assert.Equal(t, 38, report.TotalWords)
+	assert.Nil(t, report.Target)
+
+	require.NotEmpty(t, report.Keywords)
+	top := report.Keywords[0]
+	assert.Equal(t, "seeds", top.Term)
+	assert.Equal(t, 4, top.Count)
+	assert.Equal(t, 10.53, top.Density)
+	assert.True(t, top.InTitle)
+	assert.True(t, top.InH1)
+	assert.True(t, top.InMetaDescription)
+	assert.True(t, top.InURL)
+
+	for _, keyword := range report.Keywords {
+		assert.NotContains(t, []string{"the", "a", "in", "for", "when", "is"}, keyword.Term)
+	}
+
+	keywords := make(map[string]models.Keyword)
+	for _, keyword := range report.Keywords {
+		keywords[keyword.Term] = keyword
+	}
+	assert.Equal(t, 3, keywords["seedlings"].Count)
+	assert.False(t, keywords["seedlings"].InTitle)
+	assert.False(t, keywords["seedlings"].InURL)
+
+	require.NotEmpty(t, report.Bigrams)
+	assert.Equal(t, "tomato seeds", report.Bigrams[0].Term)
+	assert.Equal(t, 4, report.Bigrams[0].Count)
+	assert.True(t, report.Bigrams[0].InURL)
+	for _, bigram := range report.Bigrams {
+		assert.GreaterOrEqual(t, bigram.Count, 2)
+	}
+	assert.Empty(t, report.Trigrams)
+}
+
+// TestTargetKeyword tests where the requested keyword is found
+func TestTargetKeyword(t *testing.T) {
+	report := analyzeKeywordPage(t, "  Tomato   SEEDS ")
+
+	require.NotNil(t, report.Target)
+	target := report.Target
+	assert.Equal(t, "tomato seeds", target.Keyword)
+	assert.Equal(t, 4, target.Count)
+	assert.Equal(t, 10.53, target.Density)
+	assert.Equal(t, map[string]int{
+		"title":            1,
+		"meta-description": 1,
+		"h1":               1,
+		"headings":         0,
+		"url":              1,
+		"content":          4,
+		"first-100-words":  4,
+		"image-alt":        1,
+		"anchor-text":      1,
+	}, target.Occurrences)
+	assert.Empty(t, target.Missing)
+
+	report = analyzeKeywordPage(t, "seedlings")
+	assert.Equal(t, 3, report.Target.Count)
+	assert.Equal(t, []string{"title", "meta-description", "h1", "url"}, report.Target.Missing)
+
+	report = analyzeKeywordPage(t, " ?! ")
+	assert.Nil(t, report.Target)
+}
+
+// TestCountPhrase tests phrase matching on word sequences
+func TestCountPhrase(t *testing.T) {
+	words := lowerWords("Tomato seeds, tomato plants and more tomato seeds")
+	assert.Equal(t, 2, countPhrase(words, []string{"tomato", "seeds"}))
+	assert.Equal(t, 3, countPhrase(words, []string{"tomato"}))
+	assert.Equal(t, 0, countPhrase(words, []string{"seeds", "tomato", "plants", "and", "more", "tomato", "seeds", "x"}))
+}
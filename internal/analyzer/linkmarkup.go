@@ -0,0 +1,172 @@
+package analyzer
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// Link markup findings
+const (
+	linkNofollow          = "nofollow"
+	linkSponsored         = "sponsored"
+	linkUGC               = "ugc"
+	linkUnsafeTargetBlank = "unsafe-target-blank"
+	linkEmptyAnchor       = "empty-anchor"
+	linkImageWithoutAlt   = "image-without-alt"
+	linkGenericText       = "generic-text"
+	linkDuplicate         = "duplicate"
+)
+
+// genericAnchorTexts say nothing about where a link goes
+var genericAnchorTexts = toSet(
+	"click", "click here", "click this", "here", "go", "link", "this", "this link",
+	"more", "read more", "learn more", "see more", "more info", "more information",
+	"details", "continue", "continue reading", "find out more", "view more",
+)
+
+// linkMarkup collects the markup findings of the links met while
+// traversing the document
+type linkMarkup struct {
+	baseURL *url.URL
+	report  models.LinkMarkupReport
+	// destinations counts the links to each resolved URL, in first-seen order
+	destinations map[string]*models.DuplicateLink
+	order        []string
+}
+
+func newLinkMarkup(baseURL *url.URL) *linkMarkup {
+	return &linkMarkup{
+		baseURL: baseURL,
+		report: models.LinkMarkupReport{
+			Counts:            make(map[string]int),
+			Nofollow:          []string{},
+			Sponsored:         []string{},
+			UGC:               []string{},
+			UnsafeTargetBlank: []string{},
+			EmptyAnchors:      []string{},
+			ImagesWithoutAlt:  []string{},
+			GenericText:       []models.AnchorText{},
+			Duplicates:        []models.DuplicateLink{},
+		},
+		destinations: make(map[string]*models.DuplicateLink),
+	}
+}
+
+// add records the markup of the <a> element n linking to href
+func (m *linkMarkup) add(n *html.Node, href string) {
+	r := &m.report
+	rels := strings.Fields(strings.ToLower(getAttr(n, "rel")))
+	if containsString(rels, "nofollow") {
+		r.Nofollow = append(r.Nofollow, href)
+		r.Counts[linkNofollow]++
+	}
+	if containsString(rels, "sponsored") {
+		r.Sponsored = append(r.Sponsored, href)
+		r.Counts[linkSponsored]++
+	}
+	if containsString(rels, "ugc") {
+		r.UGC = append(r.UGC, href)
+		r.Counts[linkUGC]++
+	}
+	// noreferrer implies noopener
+	if strings.EqualFold(getAttr(n, "target"), "_blank") &&
+		!containsString(rels, "noopener") && !containsString(rels, "noreferrer") {
+		r.UnsafeTargetBlank = append(r.UnsafeTargetBlank, href)
+		r.Counts[linkUnsafeTargetBlank]++
+	}
+
+	text, images, imagesWithAlt := anchorText(n)
+	switch {
+	case text != "":
+		if genericAnchorTexts[normalizeAnchorText(text)] {
+			r.GenericText = append(r.GenericText, models.AnchorText{Href: href, Text: text})
+			r.Counts[linkGenericText]++
+		}
+	case images > imagesWithAlt:
+		r.ImagesWithoutAlt = append(r.ImagesWithoutAlt, href)
+		r.Counts[linkImageWithoutAlt]++
+	case images == 0:
+		r.EmptyAnchors = append(r.EmptyAnchors, href)
+		r.Counts[linkEmptyAnchor]++
+	}
+
+	u, err := m.baseURL.Parse(strings.TrimSpace(href))
+	if err != nil || strings.HasPrefix(strings.TrimSpace(href), "javascript:") {
+		return
+	}
+	destination := linkNormalizer.NormalizeURL(u).String()
+	duplicate, ok := m.destinations[destination]
+	if !ok {
+		duplicate = &models.DuplicateLink{URL: destination, Texts: []string{}}
+		m.destinations[destination] = duplicate
+		m.order = append(m.order, destination)
+	}
+	duplicate.Count++
+	if text != "" && !containsString(duplicate.Texts, text) {
+		duplicate.Texts = append(duplicate.Texts, text)
+	}
+}
+
+// finish returns the report once every link has been added
+func (m *linkMarkup) finish() models.LinkMarkupReport {
+	for _, destination := range m.order {
+		if duplicate := m.destinations[destination]; duplicate.Count > 1 {
+			m.report.Duplicates = append(m.report.Duplicates, *duplicate)
+			m.report.Counts[linkDuplicate]++
+		}
+	}
+	sort.SliceStable(m.report.Duplicates, func(i, j int) bool {
+		return m.report.Duplicates[i].Count > m.report.Duplicates[j].Count
+	})
+	return m.report
+}
+
+// anchorText returns the accessible text of a link: its aria-label, or its
+// text and the alt of its images, along with how many images it holds and
+// how many of those have a non-empty alt
+func anchorText(n *html.Node) (string, int, int) {
+	if label := strings.Join(strings.Fields(getAttr(n, "aria-label")), " "); label != "" {
+		return label, 0, 0
+	}
+
+	var parts []string
+	images, imagesWithAlt := 0, 0
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			parts = append(parts, node.Data)
+		case node.Type == html.ElementNode && node.Data == "img":
+			images++
+			if alt := strings.TrimSpace(getAttr(node, "alt")); alt != "" {
+				imagesWithAlt++
+				parts = append(parts, alt)
+			}
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collect(c)
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " "), images, imagesWithAlt
+}
+
+// normalizeAnchorText lowercases text and drops punctuation and arrows so
+// "Read more »" matches "read more"
+func normalizeAnchorText(text string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, text)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// TestLinkMarkup tests rel, target, anchor text and duplicate link findings
+func TestLinkMarkup(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`
+		<html><body>
+			<a href="https://partner.example.net/" rel="nofollow sponsored">Partner</a>
+			<a href="/forum/post" rel="UGC nofollow">A forum post</a>
+			<a href="https://external.example.org/" target="_blank">External</a>
+			<a href="https://safe.example.org/" target="_blank" rel="noopener">Safe</a>
+			<a href="https://private.example.org/" target="_BLANK" rel="noreferrer">Private</a>
+			<a href="/pricing">Click here</a>
+			<a href="/pricing">Read more &raquo;</a>
+			<a href="https://example.com/pricing">Pricing</a>
+			<a href="https://EXAMPLE.com:443/pricing?utm_source=nav">Pricing</a>
+			<a href="/"><img src="logo.png"></a>
+			<a href="/"><img src="logo.png" alt="Example home"></a>
+			<a href="/empty"></a>
+			<a href="/icon" aria-label="Open the settings"><svg></svg></a>
+			<a href="#top"> </a>
+		</body></html>
+	`))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://example.com/blog")
+
+	markup := newLinkMarkup(baseURL)
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			markup.add(n, getAttr(n, "href"))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+	report := markup.finish()
+
+	assert.Equal(t, []string{"https://partner.example.net/", "/forum/post"}, report.Nofollow)
+	assert.Equal(t, []string{"https://partner.example.net/"}, report.Sponsored)
+	assert.Equal(t, []string{"/forum/post"}, report.UGC)
+	assert.Equal(t, []string{"https://external.example.org/"}, report.UnsafeTargetBlank)
+	assert.Equal(t, []string{"/empty", "#top"}, report.EmptyAnchors)
+	assert.Equal(t, []string{"/"}, report.ImagesWithoutAlt)
+	assert.Equal(t, []models.AnchorText{
+		{Href: "/pricing", Text: "Click here"},
+		{Href: "/pricing", Text: "Read more »"},
+	}, report.GenericText)
+
+	require.Len(t, report.Duplicates, 2)
+	assert.Equal(t, models.DuplicateLink{
+		URL:   "https://example.com/pricing",
+		Count: 4,
+		Texts: []string{"Click here", "Read more »", "Pricing"},
+	}, report.Duplicates[0])
+	assert.Equal(t, "https://example.com/", report.Duplicates[1].URL)
+	assert.Equal(t, []string{"Example home"}, report.Duplicates[1].Texts)
+
+	assert.Equal(t, map[string]int{
+		linkNofollow:          2,
+		linkSponsored:         1,
+		linkUGC:               1,
+		linkUnsafeTargetBlank: 1,
+		linkEmptyAnchor:       2,
+		linkImageWithoutAlt:   1,
+		linkGenericText:       2,
+		linkDuplicate:         2,
+	}, report.Counts)
+}
+
+// TestNormalizeAnchorText tests matching anchor text against generic phrases
+func TestNormalizeAnchorText(t *testing.T) {
+	assert.Equal(t, "read more", normalizeAnchorText("  Read   More → "))
+	assert.Equal(t, "click here", normalizeAnchorText("Click here!"))
+	assert.Equal(t, "", normalizeAnchorText("»"))
+}
+
+// TestAnalyzeLinksChecksEachDestinationOnce tests that links differing only
+// in their normalized form are checked with one request
+func TestAnalyzeLinksChecksEachDestinationOnce(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	doc, err := html.Parse(strings.NewReader(`
+		<a href="` + server.URL + `/page">One</a>
+		<a href="` + server.URL + `/page?utm_source=footer">Two</a>
+		<a href="` + server.URL + `/./page">Three</a>
+		<a href="` + server.URL + `/other">Four</a>
+	`))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse(server.URL + "/")
+
+	result := analyzeLinks(doc, baseURL, server.Client(), LinkPolicySameSite, false)
+
+	assert.Equal(t, 4, result.Internal)
+	assert.Equal(t, 0, result.Inaccessible)
+	assert.Equal(t, int32(2), requests.Load())
+}
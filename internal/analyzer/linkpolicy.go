@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// How a link's host relates to the page's host
+const (
+	relationSameHost  = "same-host"
+	relationSameSite  = "same-site"
+	relationCrossSite = "cross-site"
+)
+
+// LinkPolicy decides which links count as internal
+type LinkPolicy string
+
+const (
+	// LinkPolicySameHost counts only links to the page's own host as internal
+	LinkPolicySameHost LinkPolicy = relationSameHost
+	// LinkPolicySameSite also counts links to other hosts of the same
+	// registrable domain, such as www.example.com and blog.example.com
+	LinkPolicySameSite LinkPolicy = relationSameSite
+)
+
+// ParseLinkPolicy returns the policy with the given name
+func ParseLinkPolicy(name string) (LinkPolicy, error) {
+	switch policy := LinkPolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case LinkPolicySameHost, LinkPolicySameSite:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown internal link policy %q; use %s or %s", name, LinkPolicySameHost, LinkPolicySameSite)
+}
+
+// isInternal reports whether a link with the given relation is internal.
+// The zero policy is LinkPolicySameSite.
+func (p LinkPolicy) isInternal(relation string) bool {
+	if p == LinkPolicySameHost {
+		return relation == relationSameHost
+	}
+	return relation == relationSameHost || relation == relationSameSite
+}
+
+// linkRelation classifies href against the page as same-host (same host
+// name and port), same-site (same registrable domain) or cross-site. Links
+// that don't lead to a web page, such as mailto: or javascript:, have no
+// relation.
+func linkRelation(href string, page *url.URL) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return relationSameHost
+	}
+	u, err := url.Parse(href)
+	if err != nil || (u.Scheme != "" && !strings.EqualFold(u.Scheme, "http") && !strings.EqualFold(u.Scheme, "https")) {
+		return ""
+	}
+	if u.Host == "" {
+		return relationSameHost
+	}
+
+	linkHost := canonicalHost(u.Hostname())
+	pageHost := canonicalHost(page.Hostname())
+	switch {
+	case linkHost == pageHost && linkPort(u, page) == linkPort(page, u):
+		return relationSameHost
+	case registrableDomain(linkHost) == registrableDomain(pageHost):
+		return relationSameSite
+	}
+	return relationCrossSite
+}
+
+// linkPort is the port of u, or the default port of its scheme. A URL without
+// a scheme, such as a scheme-relative link, takes the scheme of other.
+func linkPort(u, other *url.URL) string {
+	scheme := u.Scheme
+	if scheme == "" {
+		scheme = other.Scheme
+	}
+	return portOrDefault(u.Port(), scheme)
+}
+
+// portOrDefault returns port, or the default port of scheme when it is empty
+func portOrDefault(port, scheme string) string {
+	if port != "" {
+		return port
+	}
+	if strings.EqualFold(scheme, "https") {
+		return "443"
+	}
+	return "80"
+}
+
+// canonicalHost returns the form of a host name used for comparisons:
+// lowercase ASCII, with internationalized names in punycode and no
+// trailing dot
+func canonicalHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+		return ascii
+	}
+	return host
+}
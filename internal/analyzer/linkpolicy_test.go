@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestLinkRelation tests same-host, same-site and cross-site classification
+func TestLinkRelation(t *testing.T) {
+	testCases := []struct {
+		name string
+		href string
+		host string
+		want string
+	}{
+		{"Relative", "/about", "example.com", relationSameHost},
+		{"Fragment", "#top", "example.com", relationSameHost},
+		{"Same host", "https://example.com/a", "example.com", relationSameHost},
+		{"Host case", "https://EXAMPLE.com/a", "example.com", relationSameHost},
+		{"Trailing dot", "https://example.com./a", "example.com", relationSameHost},
+		{"Page host with port", "https://example.com:8443/a", "example.com:8443", relationSameHost},
+		{"Other port than the page", "https://example.com/a", "example.com:8443", relationSameSite},
+		{"Link with port", "https://example.com:8443/", "example.com", relationSameSite},
+		{"Default port spelled out", "https://example.com:443/a", "example.com", relationSameHost},
+		{"Other scheme", "http://example.com/a", "example.com", relationSameSite},
+		{"Scheme-relative", "//example.com/a", "example.com", relationSameHost},
+		{"www", "https://www.example.com/", "example.com", relationSameSite},
+		{"Subdomain", "https://blog.example.com/", "www.example.com", relationSameSite},
+		{"Multi-part suffix", "https://shop.example.co.uk/", "www.example.co.uk", relationSameSite},
+		{"Other site on a shared suffix", "https://other.co.uk/", "example.co.uk", relationCrossSite},
+		{"Private suffix", "https://alice.github.io/", "bob.github.io", relationCrossSite},
+		{"Other site", "https://other.com/", "example.com", relationCrossSite},
+		{"IDN in Unicode vs punycode", "https://bücher.example/", "xn--bcher-kva.example", relationSameHost},
+		{"IDN subdomain", "https://www.bücher.de/", "xn--bcher-kva.de", relationSameSite},
+		{"Mailto", "mailto:info@example.com", "example.com", ""},
+		{"JavaScript", "javascript:void(0)", "example.com", ""},
+		{"Invalid", "::::invalid", "example.com", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := &url.URL{Scheme: "https", Host: tc.host}
+			assert.Equal(t, tc.want, linkRelation(tc.href, page))
+		})
+	}
+}
+
+// TestLinkPolicy tests which relations each policy counts as internal
+func TestLinkPolicy(t *testing.T) {
+	assert.True(t, LinkPolicySameHost.isInternal(relationSameHost))
+	assert.False(t, LinkPolicySameHost.isInternal(relationSameSite))
+	assert.True(t, LinkPolicySameSite.isInternal(relationSameSite))
+	assert.False(t, LinkPolicySameSite.isInternal(relationCrossSite))
+	assert.False(t, LinkPolicySameSite.isInternal(""))
+
+	var zero LinkPolicy
+	assert.True(t, zero.isInternal(relationSameSite))
+
+	policy, err := ParseLinkPolicy(" Same-Host ")
+	require.NoError(t, err)
+	assert.Equal(t, LinkPolicySameHost, policy)
+	_, err = ParseLinkPolicy("same-origin")
+	assert.Error(t, err)
+}
+
+// TestAnalyzeLinksPolicy tests internal link counts under each policy
+func TestAnalyzeLinksPolicy(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`
+		<html><body>
+			<a href="/about">About</a>
+			<a href="https://example.com/contact">Contact</a>
+			<a href="https://blog.example.com/">Blog</a>
+			<a href="https://other.com/">Other</a>
+			<a href="mailto:info@example.com">Email</a>
+		</body></html>
+	`))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://www.example.com/")
+	client := &http.Client{Transport: &mockRoundTripper{}}
+
+	sameHost := analyzeLinks(doc, baseURL, client, LinkPolicySameHost, false)
+	assert.Equal(t, 1, sameHost.Internal)
+	assert.Equal(t, 3, sameHost.External)
+
+	sameSite := analyzeLinks(doc, baseURL, client, LinkPolicySameSite, false)
+	assert.Equal(t, 3, sameSite.Internal)
+	assert.Equal(t, 1, sameSite.External)
+	assert.Equal(t, map[string]int{
+		relationSameHost:  1,
+		relationSameSite:  2,
+		relationCrossSite: 1,
+	}, sameSite.Relations)
+}
@@ -0,0 +1,274 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// loginThreshold is the confidence at which a candidate counts as a login form
+const loginThreshold = 0.5
+
+// Evidence signals reported by the login form detector
+const (
+	signalPasswordInput        = "password-input"
+	signalCurrentPassword      = "autocomplete-current-password"
+	signalUsernameAutocomplete = "autocomplete-username"
+	signalUsernameField        = "username-field"
+	signalActionURL            = "action-url"
+	signalFormAttributes       = "form-attributes"
+	signalButtonText           = "button-text"
+	signalLabelText            = "label-text"
+	signalNewPassword          = "autocomplete-new-password"
+)
+
+// loginSignals lists every signal in reporting order together with its weight.
+// A new-password field hints at a sign-up or reset form, so it counts against.
+var loginSignals = []struct {
+	name   string
+	weight float64
+}{
+	{signalPasswordInput, 0.6},
+	{signalCurrentPassword, 0.2},
+	{signalUsernameAutocomplete, 0.2},
+	{signalUsernameField, 0.15},
+	{signalActionURL, 0.5},
+	{signalFormAttributes, 0.5},
+	{signalButtonText, 0.3},
+	{signalLabelText, 0.15},
+	{signalNewPassword, -0.3},
+}
+
+// loginIdentifierKeywords match action URLs, ids, classes and names
+var loginIdentifierKeywords = []string{
+	"login", "log-in", "log_in", "signin", "sign-in", "sign_in", "logon",
+	"anmelden", "einloggen", "connexion", "iniciar-sesion", "inloggen",
+}
+
+// loginTextKeywords match visible button and label text in several languages
+var loginTextKeywords = []string{
+	"log in", "login", "sign in", "signin", "log on",
+	"anmelden", "einloggen",
+	"iniciar sesión", "iniciar sesion", "entrar",
+	"se connecter", "connexion",
+	"accedi",
+	"inloggen", "aanmelden",
+	"logga in",
+	"zaloguj",
+	"войти",
+	"ログイン",
+	"登录", "登入",
+	"로그인",
+}
+
+// detectLoginForm reports whether the document contains a login form
+func detectLoginForm(doc *html.Node) bool {
+	return detectLoginFormScored(doc).Detected
+}
+
+// detectLoginFormScored scores every form, and every container holding a
+// password input outside a form, and returns the most convincing candidate
+func detectLoginFormScored(doc *html.Node) models.LoginFormDetection {
+	var candidates []*html.Node
+	seen := make(map[*html.Node]bool)
+
+	var crawler func(*html.Node, bool)
+	crawler = func(n *html.Node, inForm bool) {
+		if n.Type == html.ElementNode {
+			if n.Data == "form" {
+				inForm = true
+				if !seen[n] {
+					seen[n] = true
+					candidates = append(candidates, n)
+				}
+			} else if n.Data == "input" && !inForm && strings.EqualFold(getAttr(n, "type"), "password") {
+				container := loginContainer(n)
+				if !seen[container] {
+					seen[container] = true
+					candidates = append(candidates, container)
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c, inForm)
+		}
+	}
+	crawler(doc, false)
+
+	best := models.LoginFormDetection{Evidence: []string{}}
+	for _, candidate := range candidates {
+		result := scoreLoginCandidate(candidate)
+		if result.Confidence > best.Confidence {
+			best = result
+		}
+	}
+	return best
+}
+
+// loginContainer finds the closest ancestor of a form-less password input
+// that also holds a submit control, which is how div-based login UIs are built
+func loginContainer(input *html.Node) *html.Node {
+	container := input.Parent
+	for n, depth := input.Parent, 0; n != nil && depth < 6; n, depth = n.Parent, depth+1 {
+		if n.Type != html.ElementNode || n.Data == "body" || n.Data == "html" {
+			break
+		}
+		container = n
+		if containsSubmitControl(n) {
+			break
+		}
+	}
+	return container
+}
+
+func containsSubmitControl(n *html.Node) bool {
+	if n.Type == html.ElementNode {
+		if n.Data == "button" || getAttr(n, "role") == "button" {
+			return true
+		}
+		if n.Data == "input" {
+			switch strings.ToLower(getAttr(n, "type")) {
+			case "submit", "button", "image":
+				return true
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if containsSubmitControl(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// scoreLoginCandidate collects the evidence found in a form or container
+func scoreLoginCandidate(root *html.Node) models.LoginFormDetection {
+	found := make(map[string]bool)
+
+	if root.Data == "form" && containsWord(getAttr(root, "action"), loginIdentifierKeywords) {
+		found[signalActionURL] = true
+	}
+	identity := getAttr(root, "id") + " " + getAttr(root, "class") + " " + getAttr(root, "name")
+	if containsWord(identity, loginIdentifierKeywords) {
+		found[signalFormAttributes] = true
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "input":
+				inspectLoginInput(n, found)
+			case "button":
+				if containsWord(textContent(n), loginTextKeywords) {
+					found[signalButtonText] = true
+				}
+			case "label", "legend", "h1", "h2", "h3":
+				if containsWord(textContent(n), loginTextKeywords) {
+					found[signalLabelText] = true
+				}
+			default:
+				if getAttr(n, "role") == "button" && containsWord(textContent(n), loginTextKeywords) {
+					found[signalButtonText] = true
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(root)
+
+	result := models.LoginFormDetection{Evidence: []string{}}
+	var score float64
+	for _, signal := range loginSignals {
+		if found[signal.name] {
+			score += signal.weight
+			result.Evidence = append(result.Evidence, signal.name)
+		}
+	}
+
+	result.Confidence = math.Round(math.Max(0, math.Min(1, score))*100) / 100
+	result.Detected = result.Confidence >= loginThreshold
+	return result
+}
+
+func inspectLoginInput(n *html.Node, found map[string]bool) {
+	inputType := strings.ToLower(getAttr(n, "type"))
+	autocomplete := strings.Fields(strings.ToLower(getAttr(n, "autocomplete")))
+
+	for _, token := range autocomplete {
+		switch token {
+		case "username", "email":
+			found[signalUsernameAutocomplete] = true
+		case "current-password":
+			found[signalCurrentPassword] = true
+		case "new-password":
+			found[signalNewPassword] = true
+		}
+	}
+
+	switch inputType {
+	case "password":
+		found[signalPasswordInput] = true
+	case "", "text", "email":
+		identity := strings.ToLower(getAttr(n, "name") + " " + getAttr(n, "id"))
+		if strings.Contains(identity, "user") || strings.Contains(identity, "login") || strings.Contains(identity, "email") {
+			found[signalUsernameField] = true
+		}
+	case "submit", "button", "image":
+		if containsWord(getAttr(n, "value")+" "+getAttr(n, "alt"), loginTextKeywords) {
+			found[signalButtonText] = true
+		}
+	}
+}
+
+// containsKeyword reports whether s contains any keyword, ignoring case
+func containsKeyword(s string, keywords []string) bool {
+	s = strings.ToLower(s)
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether s contains any keyword as a whole word,
+// ignoring case, so that "log on" is not found in "catalog online". A
+// camelCase change also ends a word, and keywords in scripts written without
+// spaces match anywhere
+func containsWord(s string, keywords []string) bool {
+	runes := []rune(s)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	wordBreak := func(i int) bool {
+		if i == 0 || i == len(runes) {
+			return true
+		}
+		before, after := runes[i-1], runes[i]
+		return !unicode.IsLetter(before) || !unicode.IsLetter(after) ||
+			unicode.IsLower(before) && unicode.IsUpper(after)
+	}
+
+	for _, keyword := range keywords {
+		word := []rune(keyword)
+		unspaced := unicode.In(word[0], unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+		for i := 0; i+len(word) <= len(lower); i++ {
+			if string(lower[i:i+len(word)]) != keyword {
+				continue
+			}
+			if unspaced || wordBreak(i) && wordBreak(i+len(word)) {
+				return true
+			}
+		}
+	}
+	return false
+}
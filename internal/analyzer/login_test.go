@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestDetectLoginFormScored tests confidence and evidence of the login detector
+func TestDetectLoginFormScored(t *testing.T) {
+	tests := []struct {
+		name         string
+		html         string
+		detected     bool
+		wantEvidence []string
+	}{
+		{
+			name: "Div-based login outside a form",
+			html: `
+				<html><body>
+					<div class="panel">
+						<input type="email" autocomplete="username">
+						<input type="password" autocomplete="current-password">
+						<button>Sign in</button>
+					</div>
+				</body></html>
+			`,
+			detected:     true,
+			wantEvidence: []string{signalPasswordInput, signalCurrentPassword, signalUsernameAutocomplete, signalButtonText},
+		},
+		{
+			name: "German button text",
+			html: `
+				<html><body>
+					<form action="/session">
+						<input type="text" name="benutzer">
+						<input type="password" name="kennwort">
+						<button type="submit">Anmelden</button>
+					</form>
+				</body></html>
+			`,
+			detected:     true,
+			wantEvidence: []string{signalPasswordInput, signalButtonText},
+		},
+		{
+			name: "Spanish submit input without password field",
+			html: `
+				<html><body>
+					<form action="/acceso" id="iniciar-sesion">
+						<input type="email" name="email">
+						<input type="submit" value="Iniciar sesión">
+					</form>
+				</body></html>
+			`,
+			detected:     true,
+			wantEvidence: []string{signalUsernameField, signalFormAttributes, signalButtonText},
+		},
+		{
+			name: "Sign-up form with new password",
+			html: `
+				<html><body>
+					<form action="/register">
+						<input type="email" name="email">
+						<input type="password" autocomplete="new-password">
+						<button type="submit">Create account</button>
+					</form>
+				</body></html>
+			`,
+			detected:     false,
+			wantEvidence: []string{signalPasswordInput, signalUsernameField, signalNewPassword},
+		},
+		{
+			name:         "Search form",
+			html:         `<html><body><form action="/search"><input type="text" name="q"><button>Search</button></form></body></html>`,
+			detected:     false,
+			wantEvidence: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tc.html))
+			require.NoError(t, err)
+
+			result := detectLoginFormScored(doc)
+			assert.Equal(t, tc.detected, result.Detected)
+			assert.Equal(t, tc.wantEvidence, result.Evidence)
+			assert.GreaterOrEqual(t, result.Confidence, 0.0)
+			assert.LessOrEqual(t, result.Confidence, 1.0)
+		})
+	}
+}
+
+// TestDetectLoginFormScoredPicksBestCandidate ensures the strongest form wins
+func TestDetectLoginFormScoredPicksBestCandidate(t *testing.T) {
+	htmlStr := `
+		<html><body>
+			<form action="/newsletter"><input type="email" name="email"><button>Subscribe</button></form>
+			<form action="/login">
+				<input type="text" name="username">
+				<input type="password" name="password">
+				<button type="submit">Log in</button>
+			</form>
+		</body></html>
+	`
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	require.NoError(t, err)
+
+	result := detectLoginFormScored(doc)
+	assert.True(t, result.Detected)
+	assert.Equal(t, 1.0, result.Confidence)
+	assert.Contains(t, result.Evidence, signalActionURL)
+}
+
+// TestContainsWord tests that login keywords match whole words only
+func TestContainsWord(t *testing.T) {
+	testCases := []struct {
+		text     string
+		keywords []string
+		want     bool
+	}{
+		{"Log on to your account", loginTextKeywords, true},
+		{"Browse our catalog online", loginTextKeywords, false},
+		{"Entrar", loginTextKeywords, true},
+		{"Hay que concentrar esfuerzos", loginTextKeywords, false},
+		{"/account/login.php", loginIdentifierKeywords, true},
+		{"userLoginForm", loginIdentifierKeywords, true},
+		{"bloginfo", loginIdentifierKeywords, false},
+		{"ログインする", loginTextKeywords, true},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, containsWord(tc.text, tc.keywords), tc.text)
+	}
+}
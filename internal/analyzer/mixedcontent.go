@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// Mixed content categories
+const (
+	mixedActive  = "active"
+	mixedPassive = "passive"
+	mixedForm    = "form"
+)
+
+// detectMixedContent lists the http:// subresources and form targets of an
+// HTTPS page, once for every element that uses them. Images, audio and video
+// are passive content that browsers only warn about (or upgrade); everything
+// else is active content they block, including images picked from a srcset
+// or a <picture> source.
+func detectMixedContent(doc *html.Node, pageURL *url.URL, refs []resourceRef) models.MixedContentReport {
+	report := models.MixedContentReport{Items: []models.MixedContentItem{}}
+	if pageURL.Scheme != "https" {
+		return report
+	}
+	report.Applicable = true
+
+	add := func(item models.MixedContentItem) {
+		switch item.Category {
+		case mixedActive:
+			report.Active++
+		case mixedPassive:
+			report.Passive++
+		case mixedForm:
+			report.InsecureForms++
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	for _, r := range refs {
+		if !strings.HasPrefix(r.URL, "http://") {
+			continue
+		}
+		category := mixedActive
+		if (r.Type == resourceImage || r.Type == resourceMedia) && !isImageSetCandidate(r.Resource) {
+			category = mixedPassive
+		}
+		add(models.MixedContentItem{
+			URL:       r.URL,
+			Element:   r.Element,
+			Attribute: r.Attribute,
+			Category:  category,
+			Blocked:   category == mixedActive,
+		})
+	}
+
+	// Plugin content and form targets are not part of the resource inventory
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			var attr, category string
+			switch n.Data {
+			case "form":
+				attr, category = "action", mixedForm
+			case "button", "input":
+				attr, category = "formaction", mixedForm
+			case "object":
+				attr, category = "data", mixedActive
+			case "embed":
+				attr, category = "src", mixedActive
+			}
+			if attr != "" {
+				if u, err := pageURL.Parse(strings.TrimSpace(getAttr(n, attr))); err == nil && u.Scheme == "http" {
+					add(models.MixedContentItem{
+						URL:       u.String(),
+						Element:   n.Data,
+						Attribute: attr,
+						Category:  category,
+						Blocked:   category == mixedActive,
+					})
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+
+	return report
+}
+
+// isImageSetCandidate reports whether an image comes from a srcset or a
+// <picture> source, which browsers block rather than upgrade
+func isImageSetCandidate(r models.Resource) bool {
+	return r.Type == resourceImage && (r.Attribute == "srcset" || r.Element == "source")
+}
@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+const mixedContentHTML = `
+	<html><head>
+		<script src="http://cdn.example.net/lib.js"></script>
+		<link rel="stylesheet" href="http://cdn.example.net/site.css">
+		<script src="https://cdn.example.net/safe.js"></script>
+	</head><body>
+		<img src="http://images.example.net/a.png">
+		<video src="http://media.example.net/clip.mp4"></video>
+		<iframe src="http://widgets.example.net/embed"></iframe>
+		<object data="http://plugins.example.net/app.swf"></object>
+		<form action="http://example.com/subscribe"><button formaction="/secure">Go</button></form>
+		<img src="/relative.png">
+	</body></html>
+`
+
+// TestDetectMixedContent tests active, passive and form mixed content on HTTPS pages
+func TestDetectMixedContent(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(mixedContentHTML))
+	require.NoError(t, err)
+
+	pageURL, _ := url.Parse("https://example.com/")
+	report := detectMixedContent(doc, pageURL, resourceReferences(doc, pageURL))
+
+	assert.True(t, report.Applicable)
+	assert.Equal(t, 4, report.Active)
+	assert.Equal(t, 2, report.Passive)
+	assert.Equal(t, 1, report.InsecureForms)
+
+	items := make(map[string]models.MixedContentItem)
+	for _, item := range report.Items {
+		items[item.URL] = item
+	}
+
+	require.Contains(t, items, "http://cdn.example.net/lib.js")
+	assert.Equal(t, "script", items["http://cdn.example.net/lib.js"].Element)
+	assert.Equal(t, "src", items["http://cdn.example.net/lib.js"].Attribute)
+	assert.True(t, items["http://cdn.example.net/lib.js"].Blocked)
+
+	require.Contains(t, items, "http://images.example.net/a.png")
+	assert.Equal(t, mixedPassive, items["http://images.example.net/a.png"].Category)
+	assert.False(t, items["http://images.example.net/a.png"].Blocked)
+
+	require.Contains(t, items, "http://example.com/subscribe")
+	assert.Equal(t, mixedForm, items["http://example.com/subscribe"].Category)
+	assert.Equal(t, "action", items["http://example.com/subscribe"].Attribute)
+
+	assert.NotContains(t, items, "https://cdn.example.net/safe.js")
+}
+
+// TestDetectMixedContentImageSets tests that srcset and <picture> images are
+// blocked, and that every element using an insecure URL is listed
+func TestDetectMixedContentImageSets(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+		<img src="http://images.example.net/logo.png">
+		<img src="http://images.example.net/logo.png" srcset="http://images.example.net/logo@2x.png 2x">
+		<picture><source srcset="http://images.example.net/hero.webp"><img src="/hero.jpg"></picture>
+	</body></html>`))
+	require.NoError(t, err)
+
+	pageURL, _ := url.Parse("https://example.com/")
+	report := detectMixedContent(doc, pageURL, resourceReferences(doc, pageURL))
+
+	assert.Equal(t, []models.MixedContentItem{
+		{URL: "http://images.example.net/logo.png", Element: "img", Attribute: "src", Category: mixedPassive},
+		{URL: "http://images.example.net/logo.png", Element: "img", Attribute: "src", Category: mixedPassive},
+		{URL: "http://images.example.net/logo@2x.png", Element: "img", Attribute: "srcset", Category: mixedActive, Blocked: true},
+		{URL: "http://images.example.net/hero.webp", Element: "source", Attribute: "srcset", Category: mixedActive, Blocked: true},
+	}, report.Items)
+	assert.Equal(t, 2, report.Passive)
+	assert.Equal(t, 2, report.Active)
+}
+
+// TestDetectMixedContentHTTPPage ensures plain HTTP pages are not reported
+func TestDetectMixedContentHTTPPage(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(mixedContentHTML))
+	require.NoError(t, err)
+
+	pageURL, _ := url.Parse("http://example.com/")
+	report := detectMixedContent(doc, pageURL, resourceReferences(doc, pageURL))
+
+	assert.False(t, report.Applicable)
+	assert.Empty(t, report.Items)
+}
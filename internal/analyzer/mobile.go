@@ -0,0 +1,288 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// mobileViewportWidth is the CSS pixel width above which a fixed-width
+// element overflows the screen of most phones
+const mobileViewportWidth = 480
+
+// maxTapTargetPairs caps the adjacent tap targets listed
+const maxTapTargetPairs = 50
+
+// maxTapTargetSeparator is the longest text between two tap targets that
+// still leaves them adjacent, such as " | " in a footer
+const maxTapTargetSeparator = 3
+
+// srcsetWidthDescriptor matches a width descriptor such as 800w
+var srcsetWidthDescriptor = regexp.MustCompile(`\s\d+w\s*(,|$)`)
+
+// pixelLength matches a length in CSS pixels, the unit being optional for
+// width attributes
+var pixelLength = regexp.MustCompile(`^(\d+)(px)?$`)
+
+// wideAttributeElements take a width attribute in pixels that CSS seldom
+// overrides
+var wideAttributeElements = toSet("table", "iframe", "embed", "object")
+
+// tapTargetElements are the elements users tap
+var tapTargetElements = toSet("a", "button", "input", "select", "textarea")
+
+// analyzeMobile reports on the viewport, responsive images, fixed-width
+// elements, crowded tap targets and home screen metadata of the page
+func analyzeMobile(doc *html.Node) models.MobileReport {
+	report := models.MobileReport{
+		ResponsiveImages:   models.ResponsiveImageReport{SrcsetWithoutSizes: []string{}},
+		WideElements:       []models.WideElement{},
+		AdjacentTapTargets: []models.TapTargetPair{},
+		Issues:             []string{},
+	}
+	images := &report.ResponsiveImages
+	var viewports []string
+	tapTargetPairs := 0
+
+	var crawler func(*html.Node, bool)
+	crawler = func(n *html.Node, inPicture bool) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "meta":
+				switch strings.ToLower(getAttr(n, "name")) {
+				case "viewport":
+					viewports = append(viewports, getAttr(n, "content"))
+				case "theme-color":
+					if report.ThemeColor == "" {
+						report.ThemeColor = strings.TrimSpace(getAttr(n, "content"))
+					}
+				}
+			case "link":
+				for _, rel := range strings.Fields(strings.ToLower(getAttr(n, "rel"))) {
+					if (rel == "apple-touch-icon" || rel == "apple-touch-icon-precomposed") && report.AppleTouchIcon == "" {
+						report.AppleTouchIcon = strings.TrimSpace(getAttr(n, "href"))
+					}
+				}
+			case "img":
+				images.Total++
+				srcset := getAttr(n, "srcset")
+				_, hasSizes := getAttrOK(n, "sizes")
+				if srcset != "" {
+					images.WithSrcset++
+				}
+				if hasSizes {
+					images.WithSizes++
+				}
+				if inPicture {
+					images.InPicture++
+				}
+				if srcsetWidthDescriptor.MatchString(srcset) && !hasSizes {
+					images.SrcsetWithoutSizes = append(images.SrcsetWithoutSizes, getAttr(n, "src"))
+				}
+			}
+
+			if width, ok := pixelWidth(getAttr(n, "width")); ok && wideAttributeElements[n.Data] && width > mobileViewportWidth {
+				report.WideElements = append(report.WideElements, models.WideElement{Element: n.Data, Width: width, Source: "attribute"})
+			}
+			if width, ok := styleWidth(getAttr(n, "style")); ok && width > mobileViewportWidth {
+				report.WideElements = append(report.WideElements, models.WideElement{Element: n.Data, Width: width, Source: "style"})
+			}
+
+			for _, pair := range adjacentTapTargets(n) {
+				tapTargetPairs++
+				if len(report.AdjacentTapTargets) < maxTapTargetPairs {
+					report.AdjacentTapTargets = append(report.AdjacentTapTargets, pair)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c, inPicture || n.Data == "picture")
+		}
+	}
+	crawler(doc, false)
+
+	if len(viewports) > 0 {
+		// Browsers apply the last viewport declaration
+		report.Viewport = parseViewport(viewports[len(viewports)-1])
+		if len(viewports) > 1 {
+			report.Viewport.Issues = append(report.Viewport.Issues, fmt.Sprintf("%d viewport meta tags; only the last one applies", len(viewports)))
+		}
+	} else {
+		report.Viewport = models.ViewportReport{
+			Issues: []string{"no viewport meta tag; mobile browsers render the page at desktop width and scale it down"},
+		}
+	}
+	report.Issues = append(report.Issues, report.Viewport.Issues...)
+
+	if images.Total > 0 && images.WithSrcset == 0 && images.InPicture == 0 {
+		report.Issues = append(report.Issues, "no image uses srcset or <picture>; phones download desktop-sized images")
+	}
+	if n := len(images.SrcsetWithoutSizes); n > 0 {
+		report.Issues = append(report.Issues, fmt.Sprintf("%d images with srcset width descriptors have no sizes attribute", n))
+	}
+	if n := len(report.WideElements); n > 0 {
+		report.Issues = append(report.Issues, fmt.Sprintf("%d elements have a fixed width above %dpx and overflow phone screens", n, mobileViewportWidth))
+	}
+	if tapTargetPairs > 0 {
+		report.Issues = append(report.Issues, fmt.Sprintf("%d pairs of links or controls sit next to each other inline and are hard to tap apart", tapTargetPairs))
+	}
+	if report.AppleTouchIcon == "" {
+		report.Issues = append(report.Issues, "no apple-touch-icon for the home screen")
+	}
+	if report.ThemeColor == "" {
+		report.Issues = append(report.Issues, "no theme-color meta tag")
+	}
+
+	report.MobileFriendly = report.Viewport.Width == "device-width" && len(report.WideElements) == 0
+	return report
+}
+
+// parseViewport reads the content of a viewport meta tag, whose properties
+// are separated by commas or, in older pages, semicolons
+func parseViewport(content string) models.ViewportReport {
+	viewport := models.ViewportReport{
+		Present: true,
+		Content: content,
+		Issues:  []string{},
+	}
+	properties := make(map[string]string)
+	for _, part := range strings.FieldsFunc(content, func(r rune) bool { return r == ',' || r == ';' }) {
+		name, value, _ := strings.Cut(part, "=")
+		properties[strings.ToLower(strings.TrimSpace(name))] = strings.ToLower(strings.TrimSpace(value))
+	}
+
+	viewport.Width = properties["width"]
+	viewport.InitialScale = properties["initial-scale"]
+	switch width := viewport.Width; {
+	case width == "":
+		viewport.Issues = append(viewport.Issues, "viewport does not set width=device-width")
+	case width != "device-width":
+		viewport.Issues = append(viewport.Issues, fmt.Sprintf("fixed viewport width %s does not adapt to the screen", width))
+	}
+
+	if scalable, ok := properties["user-scalable"]; ok && (scalable == "no" || scalable == "0") {
+		viewport.ZoomDisabled = true
+		viewport.Issues = append(viewport.Issues, "user-scalable="+scalable+" prevents zooming")
+	}
+	if value, ok := properties["maximum-scale"]; ok {
+		if scale, err := strconv.ParseFloat(value, 64); err == nil && scale < 2 {
+			viewport.ZoomDisabled = viewport.ZoomDisabled || scale <= 1
+			viewport.Issues = append(viewport.Issues, "maximum-scale="+value+" limits zooming")
+		}
+	}
+	return viewport
+}
+
+// pixelWidth parses a width attribute; percentages are not fixed widths
+func pixelWidth(value string) (int, bool) {
+	match := pixelLength.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, false
+	}
+	width, err := strconv.Atoi(match[1])
+	return width, err == nil
+}
+
+// styleWidth returns the largest fixed width or min-width in pixels set by
+// an inline style
+func styleWidth(style string) (int, bool) {
+	widest, found := 0, false
+	for _, declaration := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "width", "min-width":
+			value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+			if !strings.HasSuffix(strings.ToLower(value), "px") {
+				continue
+			}
+			if width, ok := pixelWidth(value); ok {
+				widest, found = max(widest, width), true
+			}
+		}
+	}
+	return widest, found
+}
+
+// adjacentTapTargets returns the tap targets among the children of n that
+// follow each other with at most a short separator between them
+func adjacentTapTargets(n *html.Node) []models.TapTargetPair {
+	var pairs []models.TapTargetPair
+	var previous *html.Node
+	var separator strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case c.Type == html.TextNode:
+			separator.WriteString(c.Data)
+		case c.Type != html.ElementNode:
+			continue
+		case isTapTarget(c):
+			gap := strings.TrimSpace(separator.String())
+			if previous != nil && utf8.RuneCountInString(gap) <= maxTapTargetSeparator {
+				pairs = append(pairs, models.TapTargetPair{First: describeTapTarget(previous), Second: describeTapTarget(c)})
+			}
+			previous = c
+			separator.Reset()
+		case textBlockElements[c.Data] || containsTapTarget(c):
+			previous = nil
+			separator.Reset()
+		default:
+			separator.WriteString(textContent(c))
+		}
+	}
+	return pairs
+}
+
+func isTapTarget(n *html.Node) bool {
+	if !tapTargetElements[n.Data] {
+		return false
+	}
+	switch n.Data {
+	case "a":
+		_, hasHref := getAttrOK(n, "href")
+		return hasHref
+	case "input":
+		return !strings.EqualFold(getAttr(n, "type"), "hidden")
+	}
+	return true
+}
+
+func containsTapTarget(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (isTapTarget(c) || containsTapTarget(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeTapTarget names a tap target by its tag and label
+func describeTapTarget(n *html.Node) string {
+	var label string
+	switch n.Data {
+	case "a":
+		label, _, _ = anchorText(n)
+		if label == "" {
+			label = getAttr(n, "href")
+		}
+	case "input":
+		label = getAttr(n, "value")
+		if label == "" {
+			label = getAttr(n, "name")
+		}
+	default:
+		label = textContent(n)
+	}
+	if runes := []rune(label); len(runes) > 40 {
+		label = string(runes[:40]) + "…"
+	}
+	return fmt.Sprintf("%s %q", n.Data, label)
+}
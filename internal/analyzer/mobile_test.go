@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// TestParseViewport tests viewport meta tag validation
+func TestParseViewport(t *testing.T) {
+	testCases := []struct {
+		name         string
+		content      string
+		wantWidth    string
+		zoomDisabled bool
+		wantIssues   int
+	}{
+		{"Responsive", "width=device-width, initial-scale=1", "device-width", false, 0},
+		{"Semicolons and case", "Width=Device-Width; Initial-Scale=1.0", "device-width", false, 0},
+		{"Fixed width", "width=1024", "1024", false, 1},
+		{"No width", "initial-scale=1", "", false, 1},
+		{"Zoom disabled", "width=device-width, user-scalable=no", "device-width", true, 1},
+		{"Zoom disabled by zero", "width=device-width, user-scalable=0", "device-width", true, 1},
+		{"Zoom locked", "width=device-width, maximum-scale=1", "device-width", true, 1},
+		{"Zoom limited", "width=device-width, maximum-scale=1.5", "device-width", false, 1},
+		{"Zoom allowed", "width=device-width, maximum-scale=5", "device-width", false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viewport := parseViewport(tc.content)
+			assert.True(t, viewport.Present)
+			assert.Equal(t, tc.wantWidth, viewport.Width)
+			assert.Equal(t, tc.zoomDisabled, viewport.ZoomDisabled)
+			assert.Len(t, viewport.Issues, tc.wantIssues, viewport.Issues)
+		})
+	}
+}
+
+// TestStyleWidth tests fixed widths read from inline styles
+func TestStyleWidth(t *testing.T) {
+	testCases := []struct {
+		style     string
+		wantWidth int
+		wantFound bool
+	}{
+		{"width: 960px", 960, true},
+		{"color: red; MIN-WIDTH:1200PX !important", 1200, true},
+		{"width: 300px; min-width: 640px", 640, true},
+		{"width: 100%", 0, false},
+		{"max-width: 1200px", 0, false},
+		{"width: 50em", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tc := range testCases {
+		width, found := styleWidth(tc.style)
+		assert.Equal(t, tc.wantWidth, width, tc.style)
+		assert.Equal(t, tc.wantFound, found, tc.style)
+	}
+}
+
+// TestAnalyzeMobile tests the mobile readiness report of a page
+func TestAnalyzeMobile(t *testing.T) {
+	body := `<html><head>
+		<meta name="viewport" content="width=1024">
+		<meta name="viewport" content="width=device-width, initial-scale=1">
+		<meta name="theme-color" content="#0a84ff">
+		<link rel="apple-touch-icon" href="/apple-touch-icon.png">
+	</head><body>
+		<img src="/logo.png" alt="Logo">
+		<img src="/hero-800.jpg" srcset="/hero-800.jpg 800w, /hero-1600.jpg 1600w" alt="">
+		<img src="/team.jpg" srcset="/team.jpg 1x, /team@2x.jpg 2x" alt="">
+		<picture><source srcset="/a.webp" type="image/webp"><img src="/a.jpg" alt=""></picture>
+		<table width="960"><tr><td>Prices</td></tr></table>
+		<table width="100%"><tr><td>Fluid</td></tr></table>
+		<div style="min-width: 700px">Wide</div>
+		<img src="/banner.jpg" width="1200" alt="">
+		<p class="footer">
+			<a href="/terms">Terms</a> | <a href="/privacy">Privacy</a>
+			<span>·</span><a href="/cookies">Cookies</a>
+			and then a longer sentence before <a href="/contact">Contact</a>
+		</p>
+		<p><a href="/a">A</a><br><a href="/b">B</a></p>
+		<form><input type="hidden" name="token"><button>Go</button><input type="submit" value="Send"></form>
+		<ul><li><a href="/one">One</a></li><li><a href="/two">Two</a></li></ul>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(body))
+	require.NoError(t, err)
+
+	report := analyzeMobile(doc)
+
+	assert.True(t, report.Viewport.Present)
+	assert.Equal(t, "device-width", report.Viewport.Width)
+	assert.Equal(t, "1", report.Viewport.InitialScale)
+	assert.Equal(t, []string{"2 viewport meta tags; only the last one applies"}, report.Viewport.Issues)
+
+	assert.Equal(t, models.ResponsiveImageReport{
+		Total:              5,
+		WithSrcset:         2,
+		WithSizes:          0,
+		InPicture:          1,
+		SrcsetWithoutSizes: []string{"/hero-800.jpg"},
+	}, report.ResponsiveImages)
+
+	assert.Equal(t, []models.WideElement{
+		{Element: "table", Width: 960, Source: "attribute"},
+		{Element: "div", Width: 700, Source: "style"},
+	}, report.WideElements)
+
+	assert.Equal(t, []models.TapTargetPair{
+		{First: `a "Terms"`, Second: `a "Privacy"`},
+		{First: `a "Privacy"`, Second: `a "Cookies"`},
+		{First: `button "Go"`, Second: `input "Send"`},
+	}, report.AdjacentTapTargets)
+
+	assert.Equal(t, "/apple-touch-icon.png", report.AppleTouchIcon)
+	assert.Equal(t, "#0a84ff", report.ThemeColor)
+	assert.False(t, report.MobileFriendly)
+	assert.Len(t, report.Issues, 4)
+}
+
+// TestAnalyzeMobileBarePage tests a page with no mobile markup at all
+func TestAnalyzeMobileBarePage(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><img src="/photo.jpg"></body></html>`))
+	require.NoError(t, err)
+
+	report := analyzeMobile(doc)
+
+	assert.False(t, report.Viewport.Present)
+	assert.False(t, report.MobileFriendly)
+	assert.Empty(t, report.WideElements)
+	assert.Empty(t, report.AdjacentTapTargets)
+	assert.Equal(t, []string{
+		"no viewport meta tag; mobile browsers render the page at desktop width and scale it down",
+		"no image uses srcset or <picture>; phones download desktop-sized images",
+		"no apple-touch-icon for the home screen",
+		"no theme-color meta tag",
+	}, report.Issues)
+}
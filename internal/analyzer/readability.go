@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// ReadabilityScorer computes the readability scores of a text in one language
+type ReadabilityScorer interface {
+	Score(text string) []models.ReadabilityScore
+}
+
+// ReadabilityScorerFunc adapts a function to the ReadabilityScorer interface
+type ReadabilityScorerFunc func(text string) []models.ReadabilityScore
+
+// Score calls f(text)
+func (f ReadabilityScorerFunc) Score(text string) []models.ReadabilityScore {
+	return f(text)
+}
+
+// DefaultReadabilityScorers returns the built-in scorers by language code:
+// the usual English formulas, and the Flesch adaptations for German
+// (Amstad), Spanish (Fernández Huerta) and French (Kandel and Moles)
+func DefaultReadabilityScorers() map[string]ReadabilityScorer {
+	return map[string]ReadabilityScorer{
+		"en": ReadabilityScorerFunc(scoreEnglish),
+		"de": ReadabilityScorerFunc(func(text string) []models.ReadabilityScore {
+			s := CountTextStats(text, VowelGroupSyllables)
+			if s.Words == 0 || s.Sentences == 0 {
+				return nil
+			}
+			return []models.ReadabilityScore{readingEase("amstad", 180-s.wordsPerSentence()-58.5*s.syllablesPerWord())}
+		}),
+		"es": ReadabilityScorerFunc(func(text string) []models.ReadabilityScore {
+			s := CountTextStats(text, VowelGroupSyllables)
+			if s.Words == 0 || s.Sentences == 0 {
+				return nil
+			}
+			return []models.ReadabilityScore{readingEase("fernandez-huerta", 206.84-0.60*100*s.syllablesPerWord()-1.02*100/s.wordsPerSentence())}
+		}),
+		"fr": ReadabilityScorerFunc(func(text string) []models.ReadabilityScore {
+			s := CountTextStats(text, VowelGroupSyllables)
+			if s.Words == 0 || s.Sentences == 0 {
+				return nil
+			}
+			return []models.ReadabilityScore{readingEase("kandel-moles", 207-1.015*s.wordsPerSentence()-73.6*s.syllablesPerWord())}
+		}),
+	}
+}
+
+// TextStats are the counts readability formulas are built on
+type TextStats struct {
+	Sentences int
+	Words     int
+	Syllables int
+	// Polysyllables counts the words of three syllables or more
+	Polysyllables int
+	// Letters counts the letters and digits of the words
+	Letters int
+}
+
+func (s TextStats) wordsPerSentence() float64 {
+	return float64(s.Words) / float64(s.Sentences)
+}
+
+func (s TextStats) syllablesPerWord() float64 {
+	return float64(s.Syllables) / float64(s.Words)
+}
+
+// CountTextStats counts the sentences, words, syllables and letters of a
+// text, one line being at least one sentence. syllables counts the
+// syllables of a lowercase word.
+func CountTextStats(text string, syllables func(word string) int) TextStats {
+	var stats TextStats
+	for _, line := range strings.Split(text, "\n") {
+		stats.Sentences += len(splitSentences(line))
+		for _, word := range tokenizeWords(line) {
+			stats.Words++
+			count := syllables(strings.ToLower(word))
+			stats.Syllables += count
+			if count >= 3 {
+				stats.Polysyllables++
+			}
+			for _, r := range word {
+				if unicode.IsLetter(r) || unicode.IsDigit(r) {
+					stats.Letters++
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// EnglishSyllables estimates the syllables of an English word from its
+// vowel groups, discounting a silent final e and the -es and -ed endings
+func EnglishSyllables(word string) int {
+	word = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, word)
+	if word == "" {
+		return 0
+	}
+	if len(word) <= 3 {
+		return 1
+	}
+	switch {
+	case strings.HasSuffix(word, "le") && !strings.ContainsRune("aeiouy", rune(word[len(word)-3])):
+		// "table", "little": the final le is a syllable
+	case strings.HasSuffix(word, "es"), strings.HasSuffix(word, "ed"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "e"):
+		word = word[:len(word)-1]
+	}
+	count := 0
+	inVowels := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !inVowels {
+			count++
+		}
+		inVowels = vowel
+	}
+	return max(count, 1)
+}
+
+// VowelGroupSyllables counts the groups of vowels of a word, which
+// approximates its syllables in most European languages
+func VowelGroupSyllables(word string) int {
+	count := 0
+	inVowels := false
+	letters := false
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			letters = true
+		}
+		vowel := strings.ContainsRune("aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿœ", r)
+		if vowel && !inVowels {
+			count++
+		}
+		inVowels = vowel
+	}
+	if count == 0 && letters {
+		return 1
+	}
+	return count
+}
+
+func scoreEnglish(text string) []models.ReadabilityScore {
+	s := CountTextStats(text, EnglishSyllables)
+	if s.Words == 0 || s.Sentences == 0 {
+		return nil
+	}
+	wordsPerSentence := s.wordsPerSentence()
+	syllablesPerWord := s.syllablesPerWord()
+	lettersPerWord := float64(s.Letters) / float64(s.Words)
+
+	return []models.ReadabilityScore{
+		readingEase("flesch-reading-ease", 206.835-1.015*wordsPerSentence-84.6*syllablesPerWord),
+		gradeLevel("flesch-kincaid-grade", 0.39*wordsPerSentence+11.8*syllablesPerWord-15.59),
+		gradeLevel("gunning-fog", 0.4*(wordsPerSentence+100*float64(s.Polysyllables)/float64(s.Words))),
+		gradeLevel("smog", 1.043*math.Sqrt(float64(s.Polysyllables)*30/float64(s.Sentences))+3.1291),
+		gradeLevel("coleman-liau", 0.0588*100*lettersPerWord-0.296*100/wordsPerSentence-15.8),
+		gradeLevel("automated-readability-index", 4.71*lettersPerWord+0.5*wordsPerSentence-21.43),
+	}
+}
+
+// readingEase is a score on the Flesch scale, where higher is easier
+func readingEase(name string, score float64) models.ReadabilityScore {
+	score = math.Round(score*10) / 10
+	var interpretation string
+	switch {
+	case score >= 90:
+		interpretation = "very easy"
+	case score >= 80:
+		interpretation = "easy"
+	case score >= 70:
+		interpretation = "fairly easy"
+	case score >= 60:
+		interpretation = "standard"
+	case score >= 50:
+		interpretation = "fairly difficult"
+	case score >= 30:
+		interpretation = "difficult"
+	default:
+		interpretation = "very difficult"
+	}
+	return models.ReadabilityScore{Name: name, Score: score, Interpretation: interpretation}
+}
+
+// gradeLevel is a score in US school grades needed to understand the text
+func gradeLevel(name string, score float64) models.ReadabilityScore {
+	return models.ReadabilityScore{Name: name, Score: math.Round(score*10) / 10}
+}
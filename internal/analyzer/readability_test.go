@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEnglishSyllables tests the syllable estimate of English words
+func TestEnglishSyllables(t *testing.T) {
+	testCases := map[string]int{
+		"the":         1,
+		"cat":         1,
+		"table":       2,
+		"little":      2,
+		"make":        1,
+		"boxes":       1,
+		"wanted":      1,
+		"water":       2,
+		"banana":      3,
+		"readability": 5,
+		"rhythm":      1,
+		"":            0,
+	}
+
+	for word, want := range testCases {
+		assert.Equal(t, want, EnglishSyllables(word), word)
+	}
+}
+
+// TestCountTextStats tests the counts readability formulas are built on
+func TestCountTextStats(t *testing.T) {
+	stats := CountTextStats("The cat sat on the mat. It was a beautiful day!\nA heading", EnglishSyllables)
+
+	assert.Equal(t, 3, stats.Sentences)
+	assert.Equal(t, 13, stats.Words)
+	assert.Equal(t, 16, stats.Syllables)
+	assert.Equal(t, 1, stats.Polysyllables)
+	assert.Equal(t, 43, stats.Letters)
+}
+
+// TestReadabilityScorers tests the formulas on a simple text
+func TestReadabilityScorers(t *testing.T) {
+	scorers := DefaultReadabilityScorers()
+
+	english := scorers["en"].Score("The cat sat on the mat. The dog ran to the park.")
+	require.Len(t, english, 6)
+	assert.Equal(t, "flesch-reading-ease", english[0].Name)
+	assert.Equal(t, 116.1, english[0].Score)
+	assert.Equal(t, "very easy", english[0].Interpretation)
+	assert.Equal(t, "flesch-kincaid-grade", english[1].Name)
+	assert.Equal(t, -1.4, english[1].Score)
+	assert.Empty(t, english[1].Interpretation)
+
+	german := scorers["de"].Score("Die Katze sitzt auf der Matte. Der Hund läuft in den Park.")
+	require.Len(t, german, 1)
+	assert.Equal(t, "amstad", german[0].Name)
+	assert.Greater(t, german[0].Score, 90.0)
+
+	assert.Equal(t, "fernandez-huerta", scorers["es"].Score("El gato duerme.")[0].Name)
+	assert.Equal(t, "kandel-moles", scorers["fr"].Score("Le chat dort.")[0].Name)
+
+	assert.Nil(t, scorers["en"].Score(""))
+}
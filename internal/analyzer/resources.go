@@ -0,0 +1,332 @@
+package analyzer
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// Resource types used to group the inventory
+const (
+	resourceScript     = "script"
+	resourceStylesheet = "stylesheet"
+	resourceImage      = "image"
+	resourceMedia      = "media"
+	resourceFont       = "font"
+	resourceIframe     = "iframe"
+	resourcePreload    = "preload"
+)
+
+// maxResourceChecks bounds how many subresource requests are in flight at once
+const maxResourceChecks = 8
+
+// maxResourceBytes caps how much of a body is read to measure a resource
+// whose server reports no Content-Length
+const maxResourceBytes = 10 << 20
+
+// resourceRef is one element attribute that references a subresource
+type resourceRef struct {
+	models.Resource
+	node *html.Node
+}
+
+// extractResources lists every subresource the page references once, under
+// the first element that referenced it
+func extractResources(doc *html.Node, baseURL *url.URL) []models.Resource {
+	return uniqueResources(resourceReferences(doc, baseURL))
+}
+
+// uniqueResources keeps the first reference to each URL
+func uniqueResources(refs []resourceRef) []models.Resource {
+	resources := []models.Resource{}
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		if !seen[ref.URL] {
+			seen[ref.URL] = true
+			resources = append(resources, ref.Resource)
+		}
+	}
+	return resources
+}
+
+// resourceReferences collects the subresource references of the page in
+// document order, resolved against the page URL. A URL used by several
+// elements is listed for each of them, and non-network schemes such as data:
+// are skipped.
+func resourceReferences(doc *html.Node, baseURL *url.URL) []resourceRef {
+	var refs []resourceRef
+	pageHost := strings.ToLower(baseURL.Hostname())
+
+	add := func(n *html.Node, attr, resourceType, raw string) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			return
+		}
+		u, err := baseURL.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return
+		}
+		u.Fragment = ""
+		resolved := u.String()
+		// An element listing a URL twice, as in rel="preload stylesheet",
+		// references it once
+		for i := len(refs) - 1; i >= 0 && refs[i].node == n; i-- {
+			if refs[i].URL == resolved {
+				return
+			}
+		}
+
+		host := strings.ToLower(u.Hostname())
+		refs = append(refs, resourceRef{
+			Resource: models.Resource{
+				URL:        resolved,
+				Type:       resourceType,
+				Element:    n.Data,
+				Attribute:  attr,
+				Host:       host,
+				ThirdParty: !isFirstPartyHost(host, pageHost),
+			},
+			node: n,
+		})
+	}
+
+	addSrcset := func(n *html.Node, resourceType string) {
+		for _, candidate := range parseSrcset(getAttr(n, "srcset")) {
+			add(n, "srcset", resourceType, candidate)
+		}
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script":
+				add(n, "src", resourceScript, getAttr(n, "src"))
+			case "link":
+				rel := strings.Fields(strings.ToLower(getAttr(n, "rel")))
+				for _, r := range rel {
+					switch r {
+					case "stylesheet":
+						add(n, "href", resourceStylesheet, getAttr(n, "href"))
+					case "preload":
+						add(n, "href", preloadType(getAttr(n, "as")), getAttr(n, "href"))
+					case "modulepreload":
+						add(n, "href", resourceScript, getAttr(n, "href"))
+					}
+				}
+			case "img":
+				add(n, "src", resourceImage, getAttr(n, "src"))
+				addSrcset(n, resourceImage)
+			case "source":
+				resourceType := resourceMedia
+				if n.Parent != nil && n.Parent.Type == html.ElementNode && n.Parent.Data == "picture" {
+					resourceType = resourceImage
+				}
+				add(n, "src", resourceType, getAttr(n, "src"))
+				addSrcset(n, resourceType)
+			case "video":
+				add(n, "src", resourceMedia, getAttr(n, "src"))
+				add(n, "poster", resourceImage, getAttr(n, "poster"))
+			case "audio":
+				add(n, "src", resourceMedia, getAttr(n, "src"))
+			case "iframe":
+				add(n, "src", resourceIframe, getAttr(n, "src"))
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+
+	return refs
+}
+
+// preloadType maps the "as" attribute of a preload link to a resource type
+func preloadType(as string) string {
+	switch strings.ToLower(as) {
+	case "script":
+		return resourceScript
+	case "style":
+		return resourceStylesheet
+	case "image":
+		return resourceImage
+	case "font":
+		return resourceFont
+	case "audio", "video", "track":
+		return resourceMedia
+	case "document":
+		return resourceIframe
+	}
+	return resourcePreload
+}
+
+// parseSrcset returns the URLs of a srcset attribute, dropping the width and
+// density descriptors. URLs may contain commas, so candidates are split on
+// whitespace first as the HTML spec describes.
+func parseSrcset(srcset string) []string {
+	var urls []string
+	rest := srcset
+	for {
+		rest = strings.TrimLeft(rest, " \t\n\r\f,")
+		if rest == "" {
+			return urls
+		}
+
+		end := strings.IndexAny(rest, " \t\n\r\f")
+		if end < 0 {
+			end = len(rest)
+		}
+		candidate := rest[:end]
+		rest = rest[end:]
+
+		if strings.HasSuffix(candidate, ",") {
+			// No descriptors, the comma ends the candidate
+			candidate = strings.TrimRight(candidate, ",")
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			rest = rest[comma+1:]
+		} else {
+			rest = ""
+		}
+		urls = append(urls, candidate)
+	}
+}
+
+// registrableDomain returns the eTLD+1 of host, or host itself for IP
+// addresses and single-label names such as localhost
+func registrableDomain(host string) string {
+	host = canonicalHost(host)
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return domain
+}
+
+// isFirstPartyHost treats subdomains of the page's registrable domain as first party
+func isFirstPartyHost(host, pageHost string) bool {
+	return canonicalHost(host) == canonicalHost(pageHost) || registrableDomain(host) == registrableDomain(pageHost)
+}
+
+// checkResources requests every resource with the same checker used for
+// links, at most maxResourceChecks at a time, and records its availability,
+// status and size
+func checkResources(resources []models.Resource, client *http.Client) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxResourceChecks)
+	for i := range resources {
+		wg.Add(1)
+		go func(r *models.Resource) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			status, size, err := checkURL(r.URL, client)
+			available := err == nil && isAccessibleStatus(status)
+			if available && size < 0 {
+				// HEAD gave no Content-Length, so the body is downloaded to
+				// measure it
+				size = measureURL(r.URL, client)
+			}
+			r.Available = &available
+			r.StatusCode = status
+			r.Size = max(size, 0)
+		}(&resources[i])
+	}
+	wg.Wait()
+}
+
+// checkURL is the accessibility checker shared by links and subresources. It
+// issues a HEAD request and falls back to GET when the server does not
+// support HEAD. The size is taken from Content-Length, or measured from the
+// body when only GET was possible; it is -1 when a HEAD response carries no
+// Content-Length.
+func checkURL(link string, client *http.Client) (int, int64, error) {
+	resp, err := client.Head(link)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed && resp.StatusCode != http.StatusNotImplemented {
+		return resp.StatusCode, resp.ContentLength, nil
+	}
+
+	resp, err = client.Get(link)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, bodySize(resp), nil
+}
+
+// measureURL downloads link to measure its size, returning 0 when it can't
+func measureURL(link string, client *http.Client) int64 {
+	resp, err := client.Get(link)
+	if err != nil {
+		return 0
+	}
+	defer resp.Body.Close()
+	return bodySize(resp)
+}
+
+// bodySize is the Content-Length of a response, or the length of its body,
+// read up to maxResourceBytes, when the server reports none
+func bodySize(resp *http.Response) int64 {
+	if resp.ContentLength >= 0 {
+		return resp.ContentLength
+	}
+	size, err := io.Copy(io.Discard, io.LimitReader(resp.Body, maxResourceBytes))
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// summarizeResources groups the resources by type and origin and adds up the page weight
+func summarizeResources(resources []models.Resource, htmlSize int64) models.ResourceInventory {
+	inventory := models.ResourceInventory{
+		Total:              len(resources),
+		ThirdPartyHosts:    []string{},
+		ByType:             make(map[string]models.ResourceGroup),
+		InaccessibleByType: make(map[string][]string),
+		PageWeight:         htmlSize,
+		Resources:          resources,
+	}
+
+	hosts := make(map[string]bool)
+	for _, r := range resources {
+		group := inventory.ByType[r.Type]
+		group.Count++
+		group.Bytes += r.Size
+		if r.ThirdParty {
+			group.ThirdParty++
+			inventory.ThirdParty++
+			if !hosts[r.Host] {
+				hosts[r.Host] = true
+				inventory.ThirdPartyHosts = append(inventory.ThirdPartyHosts, r.Host)
+			}
+		} else {
+			group.FirstParty++
+			inventory.FirstParty++
+		}
+		if r.Available != nil && !*r.Available {
+			group.Inaccessible++
+			inventory.Inaccessible++
+			inventory.InaccessibleByType[r.Type] = append(inventory.InaccessibleByType[r.Type], r.URL)
+		}
+		inventory.ByType[r.Type] = group
+		inventory.PageWeight += r.Size
+	}
+	sort.Strings(inventory.ThirdPartyHosts)
+
+	return inventory
+}
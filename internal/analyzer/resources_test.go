@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+const resourcesHTML = `
+	<html><head>
+		<link rel="stylesheet" href="/css/site.css">
+		<link rel="preload" href="/fonts/body.woff2" as="font">
+		<script src="https://static.example.com/app.js"></script>
+		<script src="https://www.googletagmanager.com/gtag/js?id=G-1"></script>
+		<script>inline()</script>
+	</head><body>
+		<img src="/img/logo.png" srcset="/img/logo@2x.png 2x, /img/logo@3x.png 3x">
+		<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">
+		<picture><source srcset="/img/hero.webp"><img src="/img/hero.jpg"></picture>
+		<video src="/media/intro.mp4" poster="/img/poster.jpg"><source src="/media/intro.webm"></video>
+		<audio src="/media/jingle.mp3"></audio>
+		<iframe src="https://player.vimeo.com/video/1"></iframe>
+		<img src="/img/logo.png">
+	</body></html>
+`
+
+// TestExtractResources tests subresource extraction, typing and origin grouping
+func TestExtractResources(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(resourcesHTML))
+	require.NoError(t, err)
+
+	baseURL, _ := url.Parse("https://example.com/page")
+	resources := extractResources(doc, baseURL)
+
+	types := make(map[string]string)
+	thirdParty := make(map[string]bool)
+	for _, r := range resources {
+		types[r.URL] = r.Type
+		thirdParty[r.URL] = r.ThirdParty
+	}
+
+	assert.Equal(t, map[string]string{
+		"https://example.com/css/site.css":                resourceStylesheet,
+		"https://example.com/fonts/body.woff2":            resourceFont,
+		"https://static.example.com/app.js":               resourceScript,
+		"https://www.googletagmanager.com/gtag/js?id=G-1": resourceScript,
+		"https://example.com/img/logo.png":                resourceImage,
+		"https://example.com/img/logo@2x.png":             resourceImage,
+		"https://example.com/img/logo@3x.png":             resourceImage,
+		"https://example.com/img/hero.webp":               resourceImage,
+		"https://example.com/img/hero.jpg":                resourceImage,
+		"https://example.com/media/intro.mp4":             resourceMedia,
+		"https://example.com/img/poster.jpg":              resourceImage,
+		"https://example.com/media/intro.webm":            resourceMedia,
+		"https://example.com/media/jingle.mp3":            resourceMedia,
+		"https://player.vimeo.com/video/1":                resourceIframe,
+	}, types)
+
+	assert.False(t, thirdParty["https://static.example.com/app.js"], "subdomains are first party")
+	assert.True(t, thirdParty["https://www.googletagmanager.com/gtag/js?id=G-1"])
+	assert.True(t, thirdParty["https://player.vimeo.com/video/1"])
+}
+
+// TestParseSrcset tests srcset candidate parsing
+func TestParseSrcset(t *testing.T) {
+	tests := []struct {
+		name     string
+		srcset   string
+		expected []string
+	}{
+		{"Single URL", "a.jpg", []string{"a.jpg"}},
+		{"Density descriptors", "a.jpg 1x, b.jpg 2x", []string{"a.jpg", "b.jpg"}},
+		{"Width descriptors without spaces", "a.jpg 480w,b.jpg 800w", []string{"a.jpg", "b.jpg"}},
+		{"No descriptors", "a.jpg, b.jpg", []string{"a.jpg", "b.jpg"}},
+		{"Comma inside URL", "img.php?s=1,2 1x, c.jpg 2x", []string{"img.php?s=1,2", "c.jpg"}},
+		{"Empty", "", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseSrcset(tc.srcset))
+		})
+	}
+}
+
+// TestCheckResourcesAndSummary tests availability checks and the page weight summary
+func TestCheckResourcesAndSummary(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`
+		<html><head>
+			<script src="/app.js"></script>
+			<link rel="stylesheet" href="https://cdn.other.net/style.css">
+		</head><body><img src="/missing.png"></body></html>
+	`))
+	require.NoError(t, err)
+
+	client := &http.Client{
+		Transport: &mockRoundTripper{
+			responses: map[string]*http.Response{
+				"https://example.com/app.js": {
+					StatusCode:    http.StatusOK,
+					ContentLength: 1000,
+					Body:          io.NopCloser(bytes.NewBufferString("")),
+				},
+				"https://cdn.other.net/style.css": {
+					StatusCode:    http.StatusOK,
+					ContentLength: 500,
+					Body:          io.NopCloser(bytes.NewBufferString("")),
+				},
+			},
+		},
+	}
+
+	baseURL, _ := url.Parse("https://example.com/")
+	resources := extractResources(doc, baseURL)
+	checkResources(resources, client)
+	inventory := summarizeResources(resources, 200)
+
+	require.Len(t, inventory.Resources, 3)
+	for _, r := range inventory.Resources {
+		require.NotNil(t, r.Available)
+		assert.Equal(t, r.URL != "https://example.com/missing.png", *r.Available, r.URL)
+	}
+
+	assert.Equal(t, 3, inventory.Total)
+	assert.Equal(t, 2, inventory.FirstParty)
+	assert.Equal(t, 1, inventory.ThirdParty)
+	assert.Equal(t, []string{"cdn.other.net"}, inventory.ThirdPartyHosts)
+	assert.Equal(t, int64(1000), inventory.ByType[resourceScript].Bytes)
+	assert.Equal(t, 1, inventory.ByType[resourceStylesheet].ThirdParty)
+	assert.Equal(t, int64(1700), inventory.PageWeight)
+
+	assert.Equal(t, 1, inventory.Inaccessible)
+	assert.Equal(t, 1, inventory.ByType[resourceImage].Inaccessible)
+	assert.Equal(t, map[string][]string{
+		resourceImage: {"https://example.com/missing.png"},
+	}, inventory.InaccessibleByType)
+}
+
+// TestCheckURLFallsBackToGet tests servers that reject HEAD requests
+func TestCheckURLFallsBackToGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("body()"))
+	}))
+	defer server.Close()
+
+	status, size, err := checkURL(server.URL+"/bundle.js", server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(6), size)
+	assert.True(t, isAccessibleLink(server.URL+"/bundle.js", server.Client()))
+}
+
+// TestCheckResourcesBoundsConcurrency tests that subresource requests are
+// not all sent at once
+func TestCheckResourcesBoundsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	resources := make([]models.Resource, 40)
+	for i := range resources {
+		resources[i].URL = fmt.Sprintf("%s/img/%d.png", server.URL, i)
+	}
+	checkResources(resources, server.Client())
+
+	for _, r := range resources {
+		require.NotNil(t, r.Available)
+		assert.True(t, *r.Available)
+	}
+	assert.LessOrEqual(t, peak, maxResourceChecks)
+}
+
+// TestCheckResourcesMeasuresUnknownSize tests resources whose HEAD response
+// has no Content-Length
+func TestCheckResourcesMeasuresUnknownSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		if r.Method == http.MethodGet {
+			w.Write([]byte("console.log(1)"))
+		}
+	}))
+	defer server.Close()
+
+	status, size, err := checkURL(server.URL+"/app.js", server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int64(-1), size)
+
+	resources := []models.Resource{{URL: server.URL + "/app.js", Type: resourceScript}}
+	checkResources(resources, server.Client())
+	assert.Equal(t, int64(14), resources[0].Size)
+	assert.Equal(t, int64(14), summarizeResources(resources, 0).PageWeight)
+}
@@ -0,0 +1,288 @@
+package analyzer
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// Link categories, by URL scheme
+const (
+	linkCategoryWeb        = "web"
+	linkCategoryEmail      = "email"
+	linkCategoryPhone      = "phone"
+	linkCategoryFTP        = "ftp"
+	linkCategoryData       = "data"
+	linkCategoryJavaScript = "javascript"
+	linkCategoryFile       = "file"
+	linkCategoryApp        = "app"
+)
+
+// defaultFTPTimeout bounds an FTP check when the HTTP client has no timeout
+const defaultFTPTimeout = 10 * time.Second
+
+// maxPhoneDigits is the longest number E.164 allows
+const maxPhoneDigits = 15
+
+// linkScheme returns the lowercase scheme of href, or "" for relative links
+func linkScheme(href string) string {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		// Schemes are letters, digits, "+", "-" and "." before the first colon
+		scheme, _, ok := strings.Cut(strings.TrimSpace(href), ":")
+		if !ok || scheme == "" || strings.ContainsAny(scheme, "/?#") {
+			return ""
+		}
+		return strings.ToLower(scheme)
+	}
+	return strings.ToLower(u.Scheme)
+}
+
+// linkCategory groups a link by its scheme; any scheme not known here is
+// taken to open an app
+func linkCategory(href string) string {
+	switch linkScheme(href) {
+	case "", "http", "https":
+		return linkCategoryWeb
+	case "mailto":
+		return linkCategoryEmail
+	case "tel", "callto", "sms":
+		return linkCategoryPhone
+	case "ftp", "ftps", "sftp":
+		return linkCategoryFTP
+	case "data":
+		return linkCategoryData
+	case "javascript":
+		return linkCategoryJavaScript
+	case "file":
+		return linkCategoryFile
+	}
+	return linkCategoryApp
+}
+
+// validateSchemeLink checks the syntax of a non-web link and reports whether
+// it is valid, with the issues found
+func validateSchemeLink(href, category string) (bool, []string) {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return false, []string{"link is not a valid URL"}
+	}
+
+	switch category {
+	case linkCategoryEmail:
+		return validateMailto(u)
+	case linkCategoryPhone:
+		return validateTel(u)
+	case linkCategoryData:
+		if _, _, ok := strings.Cut(u.Opaque, ","); !ok {
+			return false, []string{"data URL has no comma before its content"}
+		}
+		return true, []string{"browsers block navigating to data URLs from a link"}
+	case linkCategoryFile:
+		return true, []string{"file links point to the visitor's own disk"}
+	case linkCategoryFTP:
+		if u.Host == "" {
+			return false, []string{"FTP link has no host"}
+		}
+		return true, []string{"most browsers no longer open FTP links"}
+	}
+	return true, nil
+}
+
+// validateMailto checks that a mailto link names at least one recipient and
+// that every recipient is a valid address (RFC 6068)
+func validateMailto(u *url.URL) (bool, []string) {
+	var addresses []string
+	to := u.Opaque
+	if to == "" {
+		to = u.Path
+	}
+	if to != "" {
+		addresses = append(addresses, strings.Split(to, ",")...)
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return false, []string{"mailto query is malformed"}
+	}
+	for _, field := range []string{"to", "cc", "bcc"} {
+		for key, values := range query {
+			if !strings.EqualFold(key, field) {
+				continue
+			}
+			for _, value := range values {
+				addresses = append(addresses, strings.Split(value, ",")...)
+			}
+		}
+	}
+
+	var issues []string
+	recipients := 0
+	for _, address := range addresses {
+		address, err := url.PathUnescape(strings.TrimSpace(address))
+		if err != nil || address == "" {
+			continue
+		}
+		recipients++
+		parsed, err := mail.ParseAddress(address)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("invalid email address %q", address))
+			continue
+		}
+		if _, domain, _ := strings.Cut(parsed.Address, "@"); !strings.Contains(domain, ".") {
+			issues = append(issues, fmt.Sprintf("email address %q has no domain name", address))
+		}
+	}
+	if recipients == 0 {
+		issues = append(issues, "mailto link has no recipient")
+	}
+	return len(issues) == 0, issues
+}
+
+// validateTel checks a telephone number against RFC 3966: a global number
+// is "+" followed by up to 15 digits, and a local number needs a
+// phone-context parameter. Dashes, dots, spaces and parentheses are allowed
+// as visual separators.
+func validateTel(u *url.URL) (bool, []string) {
+	number := u.Opaque
+	if number == "" {
+		number = u.Path
+	}
+	number, err := url.PathUnescape(number)
+	if err != nil {
+		return false, []string{"phone number is not correctly escaped"}
+	}
+	number, params, _ := strings.Cut(number, ";")
+	number = strings.TrimSpace(number)
+
+	global := strings.HasPrefix(number, "+")
+	digits := 0
+	for _, r := range strings.TrimPrefix(number, "+") {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case strings.ContainsRune("-. ()", r):
+		case (r == '*' || r == '#') && !global:
+		default:
+			return false, []string{fmt.Sprintf("phone number %q contains %q", number, r)}
+		}
+	}
+
+	switch {
+	case digits == 0:
+		return false, []string{"phone link has no number"}
+	case global && digits > maxPhoneDigits:
+		return false, []string{fmt.Sprintf("phone number %q has more than %d digits", number, maxPhoneDigits)}
+	case !global && !strings.Contains(strings.ToLower(params), "phone-context="):
+		return true, []string{fmt.Sprintf("local number %q only works in its own country; use the +country format", number)}
+	}
+	return true, nil
+}
+
+// checkFTPLink logs in anonymously to the FTP server of an ftp link and
+// checks that its path is a file or a directory. Credentials in the link
+// are never sent, and a path with control characters is rejected so that it
+// can't smuggle extra commands into the dialog
+func checkFTPLink(u *url.URL, timeout time.Duration) error {
+	if !strings.EqualFold(u.Scheme, "ftp") {
+		return fmt.Errorf("%s links can't be checked", u.Scheme)
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	if strings.IndexFunc(path, unicode.IsControl) >= 0 {
+		return fmt.Errorf("FTP path %q contains control characters", path)
+	}
+	if timeout <= 0 {
+		timeout = defaultFTPTimeout
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "21")
+	}
+
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	ftp := textproto.NewConn(conn)
+	if _, _, err := ftp.ReadResponse(220); err != nil {
+		return fmt.Errorf("unexpected FTP greeting: %w", err)
+	}
+
+	cmd := func(format string, args ...any) (int, error) {
+		id, err := ftp.Cmd(format, args...)
+		if err != nil {
+			return 0, err
+		}
+		ftp.StartResponse(id)
+		defer ftp.EndResponse(id)
+		code, _, err := ftp.ReadResponse(0)
+		return code, err
+	}
+
+	code, err := cmd("USER anonymous")
+	if err != nil {
+		return err
+	}
+	if code == 331 {
+		if code, err = cmd("PASS anonymous@"); err != nil {
+			return err
+		}
+	}
+	if code != 230 {
+		return fmt.Errorf("FTP login failed with %d", code)
+	}
+
+	// SIZE answers 213 for a file; a directory is found by changing into it
+	if code, err = cmd("SIZE %s", path); err != nil {
+		return err
+	}
+	if code != 213 {
+		if code, err = cmd("CWD %s", path); err != nil {
+			return err
+		}
+		if code != 250 {
+			return fmt.Errorf("FTP path %s not found (%d)", path, code)
+		}
+	}
+	cmd("QUIT")
+	return nil
+}
+
+// inspectSchemeLink validates a non-web link and, when enabled, checks that
+// the target of an ftp link exists
+func inspectSchemeLink(href, category string, checkFTP bool, timeout time.Duration) *models.SchemeLink {
+	link := &models.SchemeLink{
+		Href:     href,
+		Scheme:   linkScheme(href),
+		Category: category,
+		Issues:   []string{},
+	}
+	valid, issues := validateSchemeLink(href, category)
+	link.Valid = valid
+	link.Issues = append(link.Issues, issues...)
+
+	if category == linkCategoryFTP && checkFTP && valid {
+		u, _ := url.Parse(strings.TrimSpace(href))
+		accessible := true
+		if err := checkFTPLink(u, timeout); err != nil {
+			accessible = false
+			link.Error = err.Error()
+		}
+		link.Accessible = &accessible
+	}
+	return link
+}
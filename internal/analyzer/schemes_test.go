@@ -0,0 +1,268 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// startFTPServer serves a minimal FTP dialog with one file, /pub/file.txt,
+// in one directory, /pub, and returns its address along with a function
+// listing the commands received so far
+func startFTPServer(t *testing.T) (string, func() []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	var received []string
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				fmt.Fprint(conn, "220 Test FTP server ready\r\n")
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					mu.Lock()
+					received = append(received, scanner.Text())
+					mu.Unlock()
+					command, arg, _ := strings.Cut(scanner.Text(), " ")
+					switch {
+					case command == "USER":
+						fmt.Fprint(conn, "331 Password required\r\n")
+					case command == "PASS":
+						fmt.Fprint(conn, "230 Logged in\r\n")
+					case command == "SIZE" && arg == "/pub/file.txt":
+						fmt.Fprint(conn, "213 42\r\n")
+					case command == "CWD" && arg == "/pub":
+						fmt.Fprint(conn, "250 Directory changed\r\n")
+					case command == "QUIT":
+						fmt.Fprint(conn, "221 Bye\r\n")
+						return
+					default:
+						fmt.Fprint(conn, "550 Not found\r\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), received...)
+	}
+}
+
+// TestLinkCategory tests the grouping of links by scheme
+func TestLinkCategory(t *testing.T) {
+	testCases := map[string]string{
+		"/about":                       linkCategoryWeb,
+		"https://example.com":          linkCategoryWeb,
+		"HTTP://EXAMPLE.COM":           linkCategoryWeb,
+		"#section":                     linkCategoryWeb,
+		"mailto:info@example.com":      linkCategoryEmail,
+		"tel:+15551234567":             linkCategoryPhone,
+		"sms:+15551234567":             linkCategoryPhone,
+		"ftp://ftp.example.com/pub":    linkCategoryFTP,
+		"data:text/plain,hello":        linkCategoryData,
+		"javascript:void(0)":           linkCategoryJavaScript,
+		"file:///C:/report.pdf":        linkCategoryFile,
+		"whatsapp://send?text=hi":      linkCategoryApp,
+		"itms-apps://itunes.apple.com": linkCategoryApp,
+	}
+
+	for href, want := range testCases {
+		assert.Equal(t, want, linkCategory(href), href)
+	}
+}
+
+// TestValidateMailto tests mailto recipient validation
+func TestValidateMailto(t *testing.T) {
+	testCases := []struct {
+		href  string
+		valid bool
+	}{
+		{"mailto:info@example.com", true},
+		{"mailto:info@example.com,sales@example.com?subject=Hello%20there", true},
+		{"mailto:?to=info@example.com&cc=sales@example.com", true},
+		{"mailto:John%20Doe%20%3Cjohn@example.com%3E", true},
+		{"mailto:", false},
+		{"mailto:?subject=Hello", false},
+		{"mailto:info@@example.com", false},
+		{"mailto:info@localhost", false},
+		{"mailto:info@example.com?cc=not-an-address", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.href, func(t *testing.T) {
+			u, err := url.Parse(tc.href)
+			require.NoError(t, err)
+			valid, issues := validateMailto(u)
+			assert.Equal(t, tc.valid, valid)
+			assert.Equal(t, !tc.valid, len(issues) > 0)
+		})
+	}
+}
+
+// TestValidateTel tests telephone number validation
+func TestValidateTel(t *testing.T) {
+	testCases := []struct {
+		href   string
+		valid  bool
+		issues int
+	}{
+		{"tel:+15551234567", true, 0},
+		{"tel:+1-555-123-4567", true, 0},
+		{"tel:+44%2020%207946%200958", true, 0},
+		{"tel:+1 (555) 123.4567", true, 0},
+		{"tel:5551234;phone-context=+1555", true, 0},
+		{"tel:020 7946 0958", true, 1},
+		{"tel:*123#", true, 1},
+		{"tel:", false, 1},
+		{"tel:+1555CALLNOW", false, 1},
+		{"tel:+1234567890123456", false, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.href, func(t *testing.T) {
+			u, err := url.Parse(tc.href)
+			require.NoError(t, err)
+			valid, issues := validateTel(u)
+			assert.Equal(t, tc.valid, valid)
+			assert.Len(t, issues, tc.issues)
+		})
+	}
+}
+
+// TestCheckFTPLink tests FTP link checks against a local server
+func TestCheckFTPLink(t *testing.T) {
+	address, _ := startFTPServer(t)
+
+	testCases := []struct {
+		name    string
+		link    string
+		wantErr bool
+	}{
+		{"File", "ftp://" + address + "/pub/file.txt", false},
+		{"Directory", "ftp://" + address + "/pub", false},
+		{"With credentials", "ftp://user:secret@" + address + "/pub/file.txt", false},
+		{"Missing", "ftp://" + address + "/pub/missing.txt", true},
+		{"Unsupported scheme", "sftp://" + address + "/pub", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.link)
+			require.NoError(t, err)
+			err = checkFTPLink(u, time.Second)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestCheckFTPLinkInjection tests that CRLF sequences in an ftp link can't
+// add commands to the FTP dialog
+func TestCheckFTPLinkInjection(t *testing.T) {
+	address, received := startFTPServer(t)
+
+	u, err := url.Parse("ftp://x%0D%0ADELE%20a@" + address + "/pub%0D%0ADELE%20b")
+	require.NoError(t, err)
+	assert.Error(t, checkFTPLink(u, time.Second))
+
+	u, err = url.Parse("ftp://x%0D%0ADELE%20a:y%0D%0ADELE%20b@" + address + "/pub")
+	require.NoError(t, err)
+	assert.NoError(t, checkFTPLink(u, time.Second))
+
+	commands := received()
+	require.NotEmpty(t, commands)
+	assert.Equal(t, []string{"USER anonymous", "PASS anonymous@"}, commands[:2])
+	for _, command := range commands {
+		assert.NotContains(t, command, "DELE")
+	}
+}
+
+// TestAnalyzeLinksSchemes tests scheme categories and FTP checking in the link analysis
+func TestAnalyzeLinksSchemes(t *testing.T) {
+	address, _ := startFTPServer(t)
+	doc, err := html.Parse(strings.NewReader(fmt.Sprintf(`
+		<html><body>
+			<a href="/about">About</a>
+			<a href="https://other.org/">Other site</a>
+			<a href="mailto:info@example.com">Email</a>
+			<a href="mailto:info@">Broken email</a>
+			<a href="tel:+15551234567">Call</a>
+			<a href="ftp://%[1]s/pub/file.txt">File</a>
+			<a href="ftp://%[1]s/pub/missing.txt">Missing file</a>
+			<a href="javascript:void(0)">JS</a>
+			<a href="spotify:track:123">Listen</a>
+		</body></html>
+	`, address)))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://example.com/")
+	client := &http.Client{Timeout: time.Second, Transport: &mockRoundTripper{
+		responses: map[string]*http.Response{
+			"https://other.org/": {StatusCode: http.StatusOK, Body: http.NoBody},
+		},
+	}}
+
+	t.Run("Without FTP checks", func(t *testing.T) {
+		result := analyzeLinks(doc, baseURL, client, LinkPolicySameSite, false)
+
+		assert.Equal(t, map[string]int{
+			linkCategoryWeb:        2,
+			linkCategoryEmail:      2,
+			linkCategoryPhone:      1,
+			linkCategoryFTP:        2,
+			linkCategoryJavaScript: 1,
+			linkCategoryApp:        1,
+		}, result.Categories)
+		assert.Equal(t, 1, result.Internal)
+		assert.Equal(t, 1, result.External)
+		assert.Equal(t, 0, result.Inaccessible)
+
+		require.Len(t, result.SchemeLinks, 6)
+		links := make(map[string]int)
+		for i, link := range result.SchemeLinks {
+			links[link.Href] = i
+			if link.Category == linkCategoryFTP {
+				assert.Nil(t, link.Accessible)
+			}
+		}
+		assert.False(t, result.SchemeLinks[links["mailto:info@"]].Valid)
+		assert.True(t, result.SchemeLinks[links["tel:+15551234567"]].Valid)
+		assert.Equal(t, "spotify", result.SchemeLinks[links["spotify:track:123"]].Scheme)
+	})
+
+	t.Run("With FTP checks", func(t *testing.T) {
+		result := analyzeLinks(doc, baseURL, client, LinkPolicySameSite, true)
+
+		assert.Equal(t, 1, result.Inaccessible)
+		for _, link := range result.SchemeLinks {
+			if link.Category != linkCategoryFTP {
+				continue
+			}
+			require.NotNil(t, link.Accessible, link.Href)
+			assert.Equal(t, strings.HasSuffix(link.Href, "file.txt"), *link.Accessible, link.Href)
+		}
+	})
+}
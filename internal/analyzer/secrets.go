@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+	"github.com/maheshjq/web-analyzer_v1/internal/secrets"
+)
+
+// scanSecrets runs the secret rules over the text nodes, comments and inline
+// scripts of the raw page source. The source is tokenized rather than parsed
+// so that every finding keeps its line and column.
+func scanSecrets(scanner *secrets.Scanner, body []byte) models.SecretsReport {
+	if scanner == nil {
+		scanner = secrets.Default()
+	}
+
+	report := models.SecretsReport{
+		BySeverity: make(map[string]int),
+		Findings:   []models.SecretFinding{},
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(body))
+	pos := newSourcePosition()
+	rawTextParent := ""
+	for {
+		tokenType := z.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		raw := string(z.Raw())
+
+		location := ""
+		switch tokenType {
+		case html.TextToken:
+			switch rawTextParent {
+			case "script":
+				location = "script"
+			case "style":
+			default:
+				location = "text"
+			}
+		case html.CommentToken:
+			location = "comment"
+		}
+		if location != "" && strings.TrimSpace(raw) != "" {
+			report.Findings = append(report.Findings, scanner.Scan(raw, location, pos.line, pos.column)...)
+		}
+
+		rawTextParent = ""
+		if tokenType == html.StartTagToken {
+			if name, _ := z.TagName(); string(name) == "script" || string(name) == "style" {
+				rawTextParent = string(name)
+			}
+		}
+
+		pos.advance(raw)
+	}
+
+	for _, finding := range report.Findings {
+		report.BySeverity[finding.Severity]++
+	}
+	report.Total = len(report.Findings)
+
+	return report
+}
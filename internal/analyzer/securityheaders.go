@@ -0,0 +1,349 @@
+package analyzer
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// Recommended HSTS lifetimes in seconds
+const (
+	hstsMinMaxAge     = 180 * 24 * 60 * 60
+	hstsPreloadMaxAge = 365 * 24 * 60 * 60
+)
+
+// securityHeaderWeights is how much each check contributes to the 0-100 score.
+// A warning earns half the weight.
+var securityHeaderWeights = map[string]int{
+	"Content-Security-Policy":      25,
+	"Strict-Transport-Security":    20,
+	"X-Frame-Options":              15,
+	"X-Content-Type-Options":       10,
+	"Referrer-Policy":              10,
+	"Permissions-Policy":           10,
+	"Cross-Origin-Opener-Policy":   5,
+	"Cross-Origin-Embedder-Policy": 5,
+}
+
+// auditSecurityHeaders evaluates the security headers of the page response
+func auditSecurityHeaders(header http.Header, https bool) models.SecurityHeadersReport {
+	report := models.SecurityHeadersReport{}
+
+	csp, cspCheck := checkCSP(header)
+	report.CSP = csp
+	hsts, hstsCheck := checkHSTS(header, https)
+	report.HSTS = hsts
+
+	report.Checks = []models.HeaderCheck{
+		cspCheck,
+		hstsCheck,
+		checkFrameOptions(header, csp),
+		checkContentTypeOptions(header),
+		checkReferrerPolicy(header),
+		checkPermissionsPolicy(header),
+		checkCOOP(header),
+		checkCOEP(header),
+	}
+
+	var score float64
+	for _, check := range report.Checks {
+		weight := float64(securityHeaderWeights[check.Name])
+		switch check.Status {
+		case statusPass:
+			score += weight
+		case statusWarn:
+			score += weight / 2
+		}
+	}
+	report.Score = int(math.Round(score))
+	report.Grade = letterGrade(report.Score)
+
+	return report
+}
+
+func newHeaderCheck(name, value string) models.HeaderCheck {
+	return models.HeaderCheck{
+		Name:        name,
+		Present:     value != "",
+		Value:       value,
+		CheckResult: passingCheck(),
+	}
+}
+
+// parseCSP splits a policy into directives. Directive names are case
+// insensitive and, as in browsers, only the first occurrence of a name counts.
+func parseCSP(policy string) map[string][]string {
+	directives := make(map[string][]string)
+	for _, part := range strings.Split(policy, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		if _, exists := directives[name]; exists {
+			continue
+		}
+		directives[name] = fields[1:]
+	}
+	return directives
+}
+
+func checkCSP(header http.Header) (*models.CSPReport, models.HeaderCheck) {
+	value := header.Get("Content-Security-Policy")
+	reportOnly := false
+	if value == "" {
+		value = header.Get("Content-Security-Policy-Report-Only")
+		reportOnly = value != ""
+	}
+
+	check := newHeaderCheck("Content-Security-Policy", value)
+	if value == "" {
+		failCheck(&check.CheckResult, "header missing")
+		return nil, check
+	}
+
+	csp := &models.CSPReport{
+		ReportOnly: reportOnly,
+		Directives: parseCSP(value),
+		Weaknesses: []string{},
+	}
+
+	weakness := func(w string) {
+		csp.Weaknesses = append(csp.Weaknesses, w)
+		warnCheck(&check.CheckResult, w)
+	}
+
+	if reportOnly {
+		warnCheck(&check.CheckResult, "policy is report-only and not enforced")
+	}
+
+	scriptDirective := "script-src"
+	scriptSources, ok := csp.Directives[scriptDirective]
+	if !ok {
+		scriptDirective = "default-src"
+		scriptSources, ok = csp.Directives[scriptDirective]
+	}
+	if !ok {
+		weakness("no script-src or default-src, scripts are unrestricted")
+	} else {
+		// A nonce or hash makes CSP2+ browsers ignore 'unsafe-inline'
+		hasNonceOrHash := false
+		for _, source := range scriptSources {
+			lower := strings.ToLower(source)
+			if strings.HasPrefix(lower, "'nonce-") || strings.HasPrefix(lower, "'sha") {
+				hasNonceOrHash = true
+			}
+		}
+		for _, source := range scriptSources {
+			switch strings.ToLower(source) {
+			case "'unsafe-inline'":
+				if !hasNonceOrHash {
+					weakness("'unsafe-inline' allowed in " + scriptDirective)
+				}
+			case "'unsafe-eval'":
+				weakness("'unsafe-eval' allowed in " + scriptDirective)
+			case "*", "http:", "https:", "data:":
+				weakness("wildcard source " + source + " allowed in " + scriptDirective)
+			}
+		}
+	}
+
+	for _, name := range []string{"style-src", "img-src", "connect-src", "frame-src", "default-src"} {
+		if name == scriptDirective {
+			continue
+		}
+		for _, source := range csp.Directives[name] {
+			if source == "*" {
+				weakness("wildcard source * allowed in " + name)
+			}
+		}
+	}
+
+	if _, ok := csp.Directives["object-src"]; !ok {
+		if _, ok := csp.Directives["default-src"]; !ok {
+			weakness("object-src not restricted")
+		}
+	}
+	if _, ok := csp.Directives["base-uri"]; !ok {
+		weakness("base-uri not restricted")
+	}
+
+	return csp, check
+}
+
+func checkHSTS(header http.Header, https bool) (*models.HSTSReport, models.HeaderCheck) {
+	value := header.Get("Strict-Transport-Security")
+	check := newHeaderCheck("Strict-Transport-Security", value)
+
+	if !https {
+		failCheck(&check.CheckResult, "page is not served over HTTPS")
+		return nil, check
+	}
+	if value == "" {
+		failCheck(&check.CheckResult, "header missing")
+		return nil, check
+	}
+
+	hsts := &models.HSTSReport{MaxAge: -1}
+	for _, part := range strings.Split(value, ";") {
+		name, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if age, err := strconv.ParseInt(strings.Trim(strings.TrimSpace(val), `"`), 10, 64); err == nil {
+				hsts.MaxAge = age
+			}
+		case "includesubdomains":
+			hsts.IncludeSubDomains = true
+		case "preload":
+			hsts.Preload = true
+		}
+	}
+
+	switch {
+	case hsts.MaxAge < 0:
+		failCheck(&check.CheckResult, "max-age missing or invalid")
+	case hsts.MaxAge == 0:
+		failCheck(&check.CheckResult, "max-age=0 disables HSTS")
+	case hsts.MaxAge < hstsMinMaxAge:
+		warnCheck(&check.CheckResult, "max-age shorter than 180 days")
+	}
+	if !hsts.IncludeSubDomains {
+		warnCheck(&check.CheckResult, "includeSubDomains not set")
+	}
+	if hsts.Preload && (hsts.MaxAge < hstsPreloadMaxAge || !hsts.IncludeSubDomains) {
+		warnCheck(&check.CheckResult, "preload requires max-age of at least one year and includeSubDomains")
+	}
+
+	return hsts, check
+}
+
+// checkFrameOptions accepts either CSP frame-ancestors or X-Frame-Options
+func checkFrameOptions(header http.Header, csp *models.CSPReport) models.HeaderCheck {
+	value := header.Get("X-Frame-Options")
+	check := newHeaderCheck("X-Frame-Options", value)
+
+	if csp != nil && !csp.ReportOnly {
+		if ancestors, ok := csp.Directives["frame-ancestors"]; ok {
+			check.Present = true
+			check.Value = "frame-ancestors " + strings.Join(ancestors, " ")
+			for _, source := range ancestors {
+				if source == "*" {
+					warnCheck(&check.CheckResult, "frame-ancestors allows any origin")
+				}
+			}
+			return check
+		}
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "DENY", "SAMEORIGIN":
+	case "":
+		failCheck(&check.CheckResult, "neither X-Frame-Options nor CSP frame-ancestors set")
+	default:
+		if strings.HasPrefix(strings.ToUpper(value), "ALLOW-FROM") {
+			warnCheck(&check.CheckResult, "ALLOW-FROM is obsolete, use CSP frame-ancestors")
+		} else {
+			warnCheck(&check.CheckResult, "invalid X-Frame-Options value")
+		}
+	}
+	return check
+}
+
+func checkContentTypeOptions(header http.Header) models.HeaderCheck {
+	value := header.Get("X-Content-Type-Options")
+	check := newHeaderCheck("X-Content-Type-Options", value)
+
+	switch {
+	case value == "":
+		failCheck(&check.CheckResult, "header missing")
+	case !strings.EqualFold(strings.TrimSpace(value), "nosniff"):
+		failCheck(&check.CheckResult, "value must be nosniff")
+	}
+	return check
+}
+
+func checkReferrerPolicy(header http.Header) models.HeaderCheck {
+	value := header.Get("Referrer-Policy")
+	check := newHeaderCheck("Referrer-Policy", value)
+
+	if value == "" {
+		warnCheck(&check.CheckResult, "header missing, browser default applies")
+		return check
+	}
+
+	// With a comma separated list the last policy the browser knows wins
+	policy := ""
+	for _, token := range strings.Split(value, ",") {
+		token = strings.ToLower(strings.TrimSpace(token))
+		switch token {
+		case "no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin",
+			"origin", "origin-when-cross-origin", "no-referrer-when-downgrade", "unsafe-url":
+			policy = token
+		}
+	}
+
+	switch policy {
+	case "no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin":
+	case "unsafe-url":
+		failCheck(&check.CheckResult, "unsafe-url leaks full URLs to every origin")
+	case "":
+		warnCheck(&check.CheckResult, "no recognized policy")
+	default:
+		warnCheck(&check.CheckResult, policy+" leaks URL information to other origins")
+	}
+	return check
+}
+
+func checkPermissionsPolicy(header http.Header) models.HeaderCheck {
+	value := header.Get("Permissions-Policy")
+	check := newHeaderCheck("Permissions-Policy", value)
+
+	if value == "" {
+		if legacy := header.Get("Feature-Policy"); legacy != "" {
+			check.Present = true
+			check.Value = legacy
+			warnCheck(&check.CheckResult, "Feature-Policy is deprecated, use Permissions-Policy")
+		} else {
+			warnCheck(&check.CheckResult, "header missing")
+		}
+	}
+	return check
+}
+
+func checkCOOP(header http.Header) models.HeaderCheck {
+	value := header.Get("Cross-Origin-Opener-Policy")
+	check := newHeaderCheck("Cross-Origin-Opener-Policy", value)
+
+	switch policyToken(value) {
+	case "same-origin", "same-origin-allow-popups":
+	case "":
+		warnCheck(&check.CheckResult, "header missing")
+	default:
+		warnCheck(&check.CheckResult, "page shares its browsing context group with cross-origin windows")
+	}
+	return check
+}
+
+func checkCOEP(header http.Header) models.HeaderCheck {
+	value := header.Get("Cross-Origin-Embedder-Policy")
+	check := newHeaderCheck("Cross-Origin-Embedder-Policy", value)
+
+	switch policyToken(value) {
+	case "require-corp", "credentialless":
+	case "":
+		warnCheck(&check.CheckResult, "header missing")
+	default:
+		warnCheck(&check.CheckResult, "cross-origin resources are embedded without opt-in")
+	}
+	return check
+}
+
+// policyToken returns the lowercased policy of a structured header such as
+// `require-corp; report-to="default"`, without its parameters
+func policyToken(value string) string {
+	token, _, _ := strings.Cut(value, ";")
+	return strings.ToLower(strings.Trim(strings.TrimSpace(token), `"`))
+}
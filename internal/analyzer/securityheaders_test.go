@@ -0,0 +1,164 @@
+package analyzer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+func checksByName(report models.SecurityHeadersReport) map[string]models.HeaderCheck {
+	checks := make(map[string]models.HeaderCheck)
+	for _, check := range report.Checks {
+		checks[check.Name] = check
+	}
+	return checks
+}
+
+// TestAuditSecurityHeadersStrict tests a well configured response
+func TestAuditSecurityHeadersStrict(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Security-Policy", "default-src 'self'; script-src 'self' 'nonce-abc' 'unsafe-inline'; object-src 'none'; base-uri 'self'; frame-ancestors 'none'")
+	header.Set("Strict-Transport-Security", "max-age=63072000; includeSubDomains; preload")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Referrer-Policy", "no-referrer, strict-origin-when-cross-origin")
+	header.Set("Permissions-Policy", "camera=(), microphone=()")
+	header.Set("Cross-Origin-Opener-Policy", "same-origin")
+	header.Set("Cross-Origin-Embedder-Policy", `require-corp; report-to="default"`)
+
+	report := auditSecurityHeaders(header, true)
+
+	assert.Equal(t, 100, report.Score)
+	assert.Equal(t, "A", report.Grade)
+	for _, check := range report.Checks {
+		assert.Equal(t, statusPass, check.Status, "%s: %v", check.Name, check.Issues)
+	}
+
+	require.NotNil(t, report.CSP)
+	assert.Empty(t, report.CSP.Weaknesses)
+	assert.Equal(t, []string{"'self'", "'nonce-abc'", "'unsafe-inline'"}, report.CSP.Directives["script-src"])
+
+	require.NotNil(t, report.HSTS)
+	assert.Equal(t, int64(63072000), report.HSTS.MaxAge)
+	assert.True(t, report.HSTS.IncludeSubDomains)
+	assert.True(t, report.HSTS.Preload)
+
+	assert.Equal(t, "frame-ancestors 'none'", checksByName(report)["X-Frame-Options"].Value)
+}
+
+// TestAuditSecurityHeadersMissing tests a response without security headers
+func TestAuditSecurityHeadersMissing(t *testing.T) {
+	report := auditSecurityHeaders(http.Header{}, true)
+
+	checks := checksByName(report)
+	assert.Equal(t, statusFail, checks["Content-Security-Policy"].Status)
+	assert.Equal(t, statusFail, checks["Strict-Transport-Security"].Status)
+	assert.Equal(t, statusFail, checks["X-Frame-Options"].Status)
+	assert.Equal(t, statusFail, checks["X-Content-Type-Options"].Status)
+	assert.Equal(t, statusWarn, checks["Referrer-Policy"].Status)
+	assert.Nil(t, report.CSP)
+	assert.Nil(t, report.HSTS)
+	assert.Equal(t, 15, report.Score)
+	assert.Equal(t, "F", report.Grade)
+}
+
+// TestCheckCSPWeaknesses tests weaknesses found in content security policies
+func TestCheckCSPWeaknesses(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		reportOnly bool
+		expected   []string
+	}{
+		{
+			name:     "Unsafe inline and eval",
+			policy:   "default-src 'self'; script-src 'self' 'unsafe-inline' 'unsafe-eval'; base-uri 'none'",
+			expected: []string{"'unsafe-inline' allowed in script-src", "'unsafe-eval' allowed in script-src"},
+		},
+		{
+			name:     "Wildcards fall back to default-src",
+			policy:   "default-src * ; img-src *; base-uri 'self'",
+			expected: []string{"wildcard source * allowed in default-src", "wildcard source * allowed in img-src"},
+		},
+		{
+			name:     "No script restriction",
+			policy:   "img-src 'self'",
+			expected: []string{"no script-src or default-src, scripts are unrestricted", "object-src not restricted", "base-uri not restricted"},
+		},
+		{
+			name:       "Report only",
+			policy:     "default-src 'self'; base-uri 'self'",
+			reportOnly: true,
+			expected:   []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.reportOnly {
+				header.Set("Content-Security-Policy-Report-Only", tc.policy)
+			} else {
+				header.Set("Content-Security-Policy", tc.policy)
+			}
+
+			csp, check := checkCSP(header)
+			require.NotNil(t, csp)
+			assert.Equal(t, tc.expected, csp.Weaknesses)
+			assert.Equal(t, tc.reportOnly, csp.ReportOnly)
+			assert.Equal(t, statusWarn, check.Status)
+		})
+	}
+}
+
+// TestCheckHSTS tests HSTS parsing and validation
+func TestCheckHSTS(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		https    bool
+		expected string
+	}{
+		{"Long max-age with subdomains", "max-age=31536000; includeSubDomains", true, statusPass},
+		{"Short max-age", "max-age=3600; includeSubDomains", true, statusWarn},
+		{"Preload without subdomains", "max-age=31536000; preload", true, statusWarn},
+		{"Disabled", "max-age=0", true, statusFail},
+		{"Invalid", "includeSubDomains", true, statusFail},
+		{"Plain HTTP page", "max-age=31536000", false, statusFail},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Strict-Transport-Security", tc.value)
+
+			_, check := checkHSTS(header, tc.https)
+			assert.Equal(t, tc.expected, check.Status, "%v", check.Issues)
+		})
+	}
+}
+
+// TestCheckReferrerPolicy tests referrer policy evaluation
+func TestCheckReferrerPolicy(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"strict-origin-when-cross-origin", statusPass},
+		{"unsafe-url", statusFail},
+		{"no-referrer-when-downgrade", statusWarn},
+		{"unknown-policy", statusWarn},
+		{"", statusWarn},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Referrer-Policy", tc.value)
+			assert.Equal(t, tc.expected, checkReferrerPolicy(header).Status)
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// SEO rules
+const (
+	seoTitle           = "title"
+	seoMetaDescription = "meta-description"
+	seoCanonical       = "canonical"
+	seoRobots          = "robots"
+	seoHeadings        = "headings"
+	seoImageAlts       = "image-alts"
+	seoInternalLinks   = "internal-links"
+	seoURLStructure    = "url-structure"
+	seoStructuredData  = "structured-data"
+)
+
+// seoRules lists the SEO rules in reporting order
+var seoRules = []string{
+	seoTitle, seoMetaDescription, seoCanonical, seoRobots, seoHeadings,
+	seoImageAlts, seoInternalLinks, seoURLStructure, seoStructuredData,
+}
+
+// genericTitles are placeholder titles left over from templates
+var genericTitles = []string{"untitled", "untitled document", "document", "home", "index", "new page", "page title", "welcome"}
+
+// SEOConfig holds the weights and thresholds of the SEO audit. Weights are
+// relative; the score is the share of the total weight earned, and a warning
+// earns half the weight of its rule.
+type SEOConfig struct {
+	Weights              map[string]int `json:"weights"`
+	TitleMinLength       int            `json:"titleMinLength"`
+	TitleMaxLength       int            `json:"titleMaxLength"`
+	DescriptionMinLength int            `json:"descriptionMinLength"`
+	DescriptionMaxLength int            `json:"descriptionMaxLength"`
+	MinInternalLinks     int            `json:"minInternalLinks"`
+	// MinImageAltRatio is the share of images with an alt attribute below
+	// which the image-alts rule fails rather than warns
+	MinImageAltRatio float64 `json:"minImageAltRatio"`
+	MaxURLLength     int     `json:"maxUrlLength"`
+	MaxURLDepth      int     `json:"maxUrlDepth"`
+}
+
+// DefaultSEOConfig returns the weights and thresholds used when none are configured
+func DefaultSEOConfig() SEOConfig {
+	return SEOConfig{
+		Weights: map[string]int{
+			seoTitle:           15,
+			seoMetaDescription: 15,
+			seoCanonical:       10,
+			seoRobots:          10,
+			seoHeadings:        10,
+			seoImageAlts:       10,
+			seoInternalLinks:   10,
+			seoURLStructure:    10,
+			seoStructuredData:  10,
+		},
+		TitleMinLength:       30,
+		TitleMaxLength:       60,
+		DescriptionMinLength: 70,
+		DescriptionMaxLength: 160,
+		MinInternalLinks:     3,
+		MinImageAltRatio:     0.8,
+		MaxURLLength:         100,
+		MaxURLDepth:          4,
+	}
+}
+
+// LoadSEOConfig reads a JSON SEO configuration. Settings missing from the
+// file keep their default, and weights are merged rule by rule.
+func LoadSEOConfig(path string) (SEOConfig, error) {
+	config := DefaultSEOConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read SEO config: %w", err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse SEO config %s: %w", path, err)
+	}
+	for rule, weight := range config.Weights {
+		if weight < 0 {
+			return config, fmt.Errorf("negative weight for SEO rule %s", rule)
+		}
+	}
+	return config, nil
+}
+
+// withDefaults fills the settings left at their zero value
+func (c SEOConfig) withDefaults() SEOConfig {
+	defaults := DefaultSEOConfig()
+	if c.Weights == nil {
+		c.Weights = defaults.Weights
+	}
+	if c.TitleMinLength == 0 {
+		c.TitleMinLength = defaults.TitleMinLength
+	}
+	if c.TitleMaxLength == 0 {
+		c.TitleMaxLength = defaults.TitleMaxLength
+	}
+	if c.DescriptionMinLength == 0 {
+		c.DescriptionMinLength = defaults.DescriptionMinLength
+	}
+	if c.DescriptionMaxLength == 0 {
+		c.DescriptionMaxLength = defaults.DescriptionMaxLength
+	}
+	if c.MinInternalLinks == 0 {
+		c.MinInternalLinks = defaults.MinInternalLinks
+	}
+	if c.MinImageAltRatio == 0 {
+		c.MinImageAltRatio = defaults.MinImageAltRatio
+	}
+	if c.MaxURLLength == 0 {
+		c.MaxURLLength = defaults.MaxURLLength
+	}
+	if c.MaxURLDepth == 0 {
+		c.MaxURLDepth = defaults.MaxURLDepth
+	}
+	return c
+}
+
+// seoPage is what the SEO rules read from the document
+type seoPage struct {
+	titles         []string
+	descriptions   []string
+	canonicals     []string
+	robots         []string
+	headings       []int
+	images         int
+	imagesWithAlt  int
+	structuredData []string
+}
+
+// auditSEO scores the page on the configured SEO rules. Title uniqueness can
+// only be judged within the page: a single, non-generic <title>.
+func auditSEO(doc *html.Node, pageURL *url.URL, header http.Header, internalLinks int, config SEOConfig) models.SEOReport {
+	config = config.withDefaults()
+	page := collectSEOPage(doc)
+
+	checks := map[string]models.SEOCheck{
+		seoTitle:           checkTitle(page, config),
+		seoMetaDescription: checkMetaDescription(page, config),
+		seoCanonical:       checkCanonical(page, pageURL),
+		seoRobots:          checkRobots(page, header),
+		seoHeadings:        checkHeadings(page),
+		seoImageAlts:       checkImageAlts(page, config),
+		seoInternalLinks:   checkInternalLinks(internalLinks, config),
+		seoURLStructure:    checkURLStructure(pageURL, config),
+		seoStructuredData:  checkStructuredData(page),
+	}
+
+	report := models.SEOReport{Checks: []models.SEOCheck{}}
+	var earned, total float64
+	for _, name := range seoRules {
+		check := checks[name]
+		check.Name = name
+		check.Weight = config.Weights[name]
+		if check.Issues == nil {
+			check.Issues = []string{}
+		}
+		report.Checks = append(report.Checks, check)
+
+		weight := float64(check.Weight)
+		total += weight
+		switch check.Status {
+		case statusPass:
+			earned += weight
+		case statusWarn:
+			earned += weight / 2
+		}
+	}
+	if total > 0 {
+		report.Score = int(math.Round(earned / total * 100))
+	}
+	report.Grade = letterGrade(report.Score)
+
+	return report
+}
+
+func collectSEOPage(doc *html.Node) seoPage {
+	var page seoPage
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				page.titles = append(page.titles, textContent(n))
+			case "meta":
+				switch strings.ToLower(getAttr(n, "name")) {
+				case "description":
+					page.descriptions = append(page.descriptions, strings.TrimSpace(getAttr(n, "content")))
+				case "robots", "googlebot":
+					page.robots = append(page.robots, strings.ToLower(getAttr(n, "content")))
+				}
+			case "link":
+				for _, rel := range strings.Fields(strings.ToLower(getAttr(n, "rel"))) {
+					if rel == "canonical" {
+						page.canonicals = append(page.canonicals, strings.TrimSpace(getAttr(n, "href")))
+					}
+				}
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				page.headings = append(page.headings, int(n.Data[1]-'0'))
+			case "img":
+				page.images++
+				if _, ok := getAttrOK(n, "alt"); ok {
+					page.imagesWithAlt++
+				}
+			case "script":
+				if strings.EqualFold(getAttr(n, "type"), "application/ld+json") {
+					page.structuredData = append(page.structuredData, "json-ld")
+				}
+			}
+			if _, ok := getAttrOK(n, "itemscope"); ok {
+				page.structuredData = append(page.structuredData, "microdata")
+			}
+			if getAttr(n, "typeof") != "" {
+				page.structuredData = append(page.structuredData, "rdfa")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+	return page
+}
+
+func checkTitle(page seoPage, config SEOConfig) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	if len(page.titles) == 0 || page.titles[0] == "" {
+		failCheck(&check.CheckResult, "missing or empty <title>")
+		return check
+	}
+
+	title := page.titles[0]
+	check.Value = title
+	if len(page.titles) > 1 {
+		warnCheck(&check.CheckResult, fmt.Sprintf("%d <title> elements; only the first is used", len(page.titles)))
+	}
+	if length := utf8.RuneCountInString(title); length < config.TitleMinLength {
+		warnCheck(&check.CheckResult, fmt.Sprintf("title is %d characters, shorter than %d", length, config.TitleMinLength))
+	} else if length > config.TitleMaxLength {
+		warnCheck(&check.CheckResult, fmt.Sprintf("title is %d characters, longer than %d and likely truncated", length, config.TitleMaxLength))
+	}
+	for _, generic := range genericTitles {
+		if strings.EqualFold(title, generic) {
+			warnCheck(&check.CheckResult, "title is a generic placeholder")
+		}
+	}
+	return check
+}
+
+func checkMetaDescription(page seoPage, config SEOConfig) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	if len(page.descriptions) == 0 || page.descriptions[0] == "" {
+		failCheck(&check.CheckResult, "missing or empty meta description")
+		return check
+	}
+
+	description := page.descriptions[0]
+	check.Value = description
+	if len(page.descriptions) > 1 {
+		warnCheck(&check.CheckResult, fmt.Sprintf("%d meta descriptions", len(page.descriptions)))
+	}
+	if length := utf8.RuneCountInString(description); length < config.DescriptionMinLength {
+		warnCheck(&check.CheckResult, fmt.Sprintf("description is %d characters, shorter than %d", length, config.DescriptionMinLength))
+	} else if length > config.DescriptionMaxLength {
+		warnCheck(&check.CheckResult, fmt.Sprintf("description is %d characters, longer than %d and likely truncated", length, config.DescriptionMaxLength))
+	}
+	return check
+}
+
+func checkCanonical(page seoPage, pageURL *url.URL) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	if len(page.canonicals) == 0 {
+		warnCheck(&check.CheckResult, "no canonical link")
+		return check
+	}
+
+	canonical := page.canonicals[0]
+	check.Value = canonical
+	if len(page.canonicals) > 1 {
+		failCheck(&check.CheckResult, fmt.Sprintf("%d canonical links; search engines ignore all of them", len(page.canonicals)))
+		return check
+	}
+	u, err := url.Parse(canonical)
+	if err != nil || canonical == "" {
+		failCheck(&check.CheckResult, "canonical URL is invalid")
+		return check
+	}
+	if !u.IsAbs() {
+		warnCheck(&check.CheckResult, "canonical URL is relative")
+		u = pageURL.ResolveReference(u)
+	}
+	if !strings.EqualFold(u.Host, pageURL.Host) {
+		warnCheck(&check.CheckResult, "canonical points to another host "+u.Host)
+	}
+	return check
+}
+
+func checkRobots(page seoPage, header http.Header) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	directives := append([]string{}, page.robots...)
+	for _, value := range header.Values("X-Robots-Tag") {
+		directives = append(directives, strings.ToLower(value))
+	}
+	check.Value = strings.Join(directives, ", ")
+
+	for _, value := range directives {
+		for _, directive := range strings.Split(value, ",") {
+			// X-Robots-Tag may be scoped to a user agent, e.g. "googlebot: noindex"
+			if agent, d, ok := strings.Cut(directive, ":"); ok && strings.TrimSpace(agent) != "unavailable_after" {
+				directive = d
+			}
+			switch strings.TrimSpace(directive) {
+			case "noindex", "none":
+				failCheck(&check.CheckResult, "page is excluded from indexing ("+strings.TrimSpace(directive)+")")
+			case "nofollow":
+				warnCheck(&check.CheckResult, "links on the page are not followed")
+			}
+		}
+	}
+	return check
+}
+
+func checkHeadings(page seoPage) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	h1 := 0
+	for _, level := range page.headings {
+		if level == 1 {
+			h1++
+		}
+	}
+	check.Value = strconv.Itoa(h1) + " h1"
+
+	switch {
+	case h1 == 0:
+		failCheck(&check.CheckResult, "no <h1>")
+	case h1 > 1:
+		warnCheck(&check.CheckResult, fmt.Sprintf("%d <h1> elements", h1))
+	}
+	previous := 0
+	for _, level := range page.headings {
+		if previous > 0 && level > previous+1 {
+			warnCheck(&check.CheckResult, fmt.Sprintf("heading level skipped from h%d to h%d", previous, level))
+			break
+		}
+		previous = level
+	}
+	return check
+}
+
+func checkImageAlts(page seoPage, config SEOConfig) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	check.Value = fmt.Sprintf("%d/%d images with alt", page.imagesWithAlt, page.images)
+	if page.images == 0 || page.imagesWithAlt == page.images {
+		return check
+	}
+
+	missing := page.images - page.imagesWithAlt
+	issue := fmt.Sprintf("%d images without an alt attribute", missing)
+	if float64(page.imagesWithAlt)/float64(page.images) < config.MinImageAltRatio {
+		failCheck(&check.CheckResult, issue)
+	} else {
+		warnCheck(&check.CheckResult, issue)
+	}
+	return check
+}
+
+func checkInternalLinks(internalLinks int, config SEOConfig) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck(), Value: strconv.Itoa(internalLinks)}
+	switch {
+	case internalLinks == 0:
+		failCheck(&check.CheckResult, "no internal links")
+	case internalLinks < config.MinInternalLinks:
+		warnCheck(&check.CheckResult, fmt.Sprintf("only %d internal links, fewer than %d", internalLinks, config.MinInternalLinks))
+	}
+	return check
+}
+
+func checkURLStructure(pageURL *url.URL, config SEOConfig) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck(), Value: pageURL.String()}
+
+	if length := utf8.RuneCountInString(pageURL.String()); length > config.MaxURLLength {
+		warnCheck(&check.CheckResult, fmt.Sprintf("URL is %d characters, longer than %d", length, config.MaxURLLength))
+	}
+	path := strings.Trim(pageURL.Path, "/")
+	if depth := strings.Count(path, "/") + 1; path != "" && depth > config.MaxURLDepth {
+		warnCheck(&check.CheckResult, fmt.Sprintf("URL path is %d levels deep, more than %d", depth, config.MaxURLDepth))
+	}
+	if path != strings.ToLower(path) {
+		warnCheck(&check.CheckResult, "URL path contains uppercase characters")
+	}
+	if strings.Contains(path, "_") {
+		warnCheck(&check.CheckResult, "URL path uses underscores instead of hyphens")
+	}
+	query := pageURL.Query()
+	if len(query) > 2 {
+		warnCheck(&check.CheckResult, fmt.Sprintf("URL has %d query parameters", len(query)))
+	}
+	for key := range query {
+		if lower := strings.ToLower(key); lower == "sessionid" || lower == "sid" || lower == "phpsessid" || lower == "jsessionid" {
+			failCheck(&check.CheckResult, "URL carries a session ID")
+		}
+	}
+	return check
+}
+
+func checkStructuredData(page seoPage) models.SEOCheck {
+	check := models.SEOCheck{CheckResult: passingCheck()}
+	var formats []string
+	for _, format := range page.structuredData {
+		if !containsString(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	check.Value = strings.Join(formats, ", ")
+	if len(formats) == 0 {
+		warnCheck(&check.CheckResult, "no structured data (JSON-LD, microdata or RDFa)")
+	}
+	return check
+}
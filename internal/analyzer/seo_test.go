@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+func seoChecks(report models.SEOReport) map[string]models.SEOCheck {
+	checks := make(map[string]models.SEOCheck)
+	for _, check := range report.Checks {
+		checks[check.Name] = check
+	}
+	return checks
+}
+
+// TestAuditSEOWellOptimized tests a page that passes every rule
+func TestAuditSEOWellOptimized(t *testing.T) {
+	body := `<!DOCTYPE html><html><head>
+		<title>Handmade Oak Furniture for Every Room | Example Shop</title>
+		<meta name="description" content="Browse handmade oak tables, chairs and shelves built to order in our workshop, with free delivery on every order.">
+		<meta name="robots" content="index, follow">
+		<link rel="canonical" href="https://www.example.com/furniture/oak">
+		<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Store"}</script>
+	</head><body>
+		<h1>Oak furniture</h1><h2>Tables</h2><h3>Dining tables</h3><h2>Chairs</h2>
+		<img src="table.jpg" alt="Oak dining table"><img src="divider.png" alt="">
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(body))
+	require.NoError(t, err)
+	pageURL, _ := url.Parse("https://www.example.com/furniture/oak")
+
+	report := auditSEO(doc, pageURL, http.Header{}, 12, DefaultSEOConfig())
+
+	for _, check := range report.Checks {
+		assert.Equal(t, statusPass, check.Status, "%s: %v", check.Name, check.Issues)
+	}
+	assert.Equal(t, 100, report.Score)
+	assert.Equal(t, "A", report.Grade)
+	assert.Len(t, report.Checks, len(seoRules))
+}
+
+// TestAuditSEOProblems tests the warnings and failures of each rule
+func TestAuditSEOProblems(t *testing.T) {
+	body := `<html><head>
+		<title>Home</title><title>Second</title>
+		<meta name="description" content="Too short.">
+		<link rel="canonical" href="/a"><link rel="canonical" href="/b">
+		<meta name="robots" content="noindex, nofollow">
+	</head><body>
+		<h2>Intro</h2><h4>Skipped</h4>
+		<img src="a.jpg"><img src="b.jpg"><img src="c.jpg" alt="C">
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(body))
+	require.NoError(t, err)
+	pageURL, _ := url.Parse("https://www.example.com/Shop_Items/a/b/c/d/e?id=1&sort=asc&page=2&sessionid=abc")
+
+	report := auditSEO(doc, pageURL, http.Header{}, 1, DefaultSEOConfig())
+	checks := seoChecks(report)
+
+	assert.Equal(t, statusWarn, checks[seoTitle].Status)
+	assert.Len(t, checks[seoTitle].Issues, 3)
+	assert.Equal(t, statusWarn, checks[seoMetaDescription].Status)
+	assert.Equal(t, statusFail, checks[seoCanonical].Status)
+	assert.Equal(t, statusFail, checks[seoRobots].Status)
+	assert.Len(t, checks[seoRobots].Issues, 2)
+	assert.Equal(t, statusFail, checks[seoHeadings].Status)
+	assert.Contains(t, checks[seoHeadings].Issues, "heading level skipped from h2 to h4")
+	assert.Equal(t, statusFail, checks[seoImageAlts].Status)
+	assert.Equal(t, "1/3 images with alt", checks[seoImageAlts].Value)
+	assert.Equal(t, statusWarn, checks[seoInternalLinks].Status)
+	assert.Equal(t, statusFail, checks[seoURLStructure].Status)
+	assert.Len(t, checks[seoURLStructure].Issues, 5)
+	assert.Equal(t, statusWarn, checks[seoStructuredData].Status)
+
+	// 15/2 + 15/2 + 10/2 + 10/2
+	assert.Equal(t, 25, report.Score)
+	assert.Equal(t, "F", report.Grade)
+}
+
+// TestAuditSEOMissing tests a page missing title, description and canonical
+func TestAuditSEOMissing(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><h1>Only a heading</h1><p itemscope itemtype="https://schema.org/Thing">x</p></body></html>`))
+	require.NoError(t, err)
+	pageURL, _ := url.Parse("https://www.example.com/")
+	header := http.Header{}
+	header.Set("X-Robots-Tag", "googlebot: noindex")
+
+	checks := seoChecks(auditSEO(doc, pageURL, header, 0, SEOConfig{}))
+
+	assert.Equal(t, statusFail, checks[seoTitle].Status)
+	assert.Equal(t, statusFail, checks[seoMetaDescription].Status)
+	assert.Equal(t, statusWarn, checks[seoCanonical].Status)
+	assert.Equal(t, statusFail, checks[seoRobots].Status)
+	assert.Equal(t, statusFail, checks[seoInternalLinks].Status)
+	assert.Equal(t, statusPass, checks[seoStructuredData].Status)
+	assert.Equal(t, "microdata", checks[seoStructuredData].Value)
+}
+
+// TestAuditSEOCrossHostCanonical tests that a canonical on another host lowers the score
+func TestAuditSEOCrossHostCanonical(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head><link rel="canonical" href="https://other.example.net/oak"></head></html>`))
+	require.NoError(t, err)
+	pageURL, _ := url.Parse("https://www.example.com/furniture/oak")
+
+	checks := seoChecks(auditSEO(doc, pageURL, http.Header{}, 12, DefaultSEOConfig()))
+
+	assert.Equal(t, statusWarn, checks[seoCanonical].Status)
+	assert.Equal(t, []string{"canonical points to another host other.example.net"}, checks[seoCanonical].Issues)
+}
+
+// TestAuditSEOConfig tests custom weights and thresholds
+func TestAuditSEOConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seo.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"weights": {"structured-data": 0, "title": 50},
+		"titleMinLength": 5
+	}`), 0o600))
+
+	config, err := LoadSEOConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, 50, config.Weights[seoTitle])
+	assert.Equal(t, 0, config.Weights[seoStructuredData])
+	assert.Equal(t, 15, config.Weights[seoMetaDescription])
+	assert.Equal(t, 5, config.TitleMinLength)
+	assert.Equal(t, 60, config.TitleMaxLength)
+
+	doc, err := html.Parse(strings.NewReader(`<html><head><title>Short</title></head><body></body></html>`))
+	require.NoError(t, err)
+	pageURL, _ := url.Parse("https://www.example.com/")
+	checks := seoChecks(auditSEO(doc, pageURL, http.Header{}, 0, config))
+
+	assert.Equal(t, statusPass, checks[seoTitle].Status)
+	assert.Equal(t, 50, checks[seoTitle].Weight)
+	assert.Equal(t, 0, checks[seoStructuredData].Weight)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"weights": {"title": -1}}`), 0o600))
+	_, err = LoadSEOConfig(path)
+	assert.Error(t, err)
+}
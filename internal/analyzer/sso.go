@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// identityProvider describes how a third-party sign-in option shows up in markup
+type identityProvider struct {
+	name     string
+	protocol string
+	// endpoints are the URLs the provider signs in through, see matchesEndpoint
+	endpoints []string
+	// buttonText is matched against link and button labels, e.g. "with google"
+	buttonText string
+}
+
+var identityProviders = []identityProvider{
+	{"Google", "oidc", []string{"accounts.google.com", "apis.google.com/js/platform.js"}, "with google"},
+	{"Microsoft", "oidc", []string{"login.microsoftonline.com", "login.live.com", "alcdn.msauth.net"}, "with microsoft"},
+	{"Apple", "oidc", []string{"appleid.apple.com", "appleid.cdn-apple.com/appleauth"}, "with apple"},
+	{"GitHub", "oauth2", []string{"github.com/login/oauth"}, "with github"},
+	{"Facebook", "oauth2", []string{"facebook.com/dialog/oauth", "connect.facebook.net/sdk.js"}, "with facebook"},
+	{"LinkedIn", "oauth2", []string{"linkedin.com/oauth"}, "with linkedin"},
+	{"Twitter", "oauth2", []string{"api.twitter.com/oauth", "twitter.com/i/oauth2"}, "with twitter"},
+	{"Okta", "oidc", []string{"okta.com", "oktacdn.com"}, "with okta"},
+	{"Auth0", "oidc", []string{"auth0.com"}, ""},
+}
+
+// detectSSO inspects links, buttons, form actions and script sources for
+// known identity providers and generic SAML or OpenID Connect redirects
+func detectSSO(doc *html.Node, baseURL *url.URL) models.SSODetection {
+	providers := make(map[string]*models.SSOProvider)
+
+	record := func(name, protocol, evidence string) {
+		p, ok := providers[name]
+		if !ok {
+			p = &models.SSOProvider{Name: name, Protocol: protocol, Evidence: []string{}}
+			providers[name] = p
+		}
+		for _, e := range p.Evidence {
+			if e == evidence {
+				return
+			}
+		}
+		p.Evidence = append(p.Evidence, evidence)
+	}
+
+	inspectURL := func(kind, raw string) {
+		u, err := baseURL.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return
+		}
+		protocol := ssoProtocol(u)
+		for _, provider := range identityProviders {
+			if matchesEndpoint(provider.endpoints, u) {
+				if protocol == "" {
+					protocol = provider.protocol
+				}
+				record(provider.name, protocol, kind+":"+u.String())
+				return
+			}
+		}
+		if protocol != "" {
+			record(strings.ToLower(u.Hostname()), protocol, kind+":"+u.String())
+		}
+	}
+
+	inspectText := func(text string) {
+		lower := strings.ToLower(text)
+		for _, provider := range identityProviders {
+			if provider.buttonText != "" && strings.Contains(lower, provider.buttonText) {
+				record(provider.name, provider.protocol, "button-text:"+text)
+			}
+		}
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "a":
+				if href := getAttr(n, "href"); href != "" {
+					inspectURL("link", href)
+				}
+				inspectText(textContent(n))
+			case "button":
+				if action := getAttr(n, "formaction"); action != "" {
+					inspectURL("form-action", action)
+				}
+				inspectText(textContent(n))
+			case "input":
+				switch strings.ToLower(getAttr(n, "type")) {
+				case "submit", "button":
+					inspectText(getAttr(n, "value"))
+				}
+			case "form":
+				if action := getAttr(n, "action"); action != "" {
+					inspectURL("form-action", action)
+				}
+			case "script", "iframe":
+				if src := getAttr(n, "src"); src != "" {
+					inspectURL(n.Data, src)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+
+	result := models.SSODetection{Providers: []models.SSOProvider{}}
+	for _, p := range providers {
+		result.Providers = append(result.Providers, *p)
+	}
+	sort.Slice(result.Providers, func(i, j int) bool {
+		return result.Providers[i].Name < result.Providers[j].Name
+	})
+	result.Detected = len(result.Providers) > 0
+	return result
+}
+
+// matchesEndpoint reports whether u points at one of the endpoints, which
+// are "host/path" patterns: the host matches as a suffix and the optional
+// path part must appear somewhere in the URL path
+func matchesEndpoint(endpoints []string, u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, endpoint := range endpoints {
+		endpointHost, endpointPath, _ := strings.Cut(endpoint, "/")
+		if host != endpointHost && !strings.HasSuffix(host, "."+endpointHost) {
+			continue
+		}
+		if endpointPath == "" || strings.Contains(strings.ToLower(u.Path), endpointPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// ssoProtocol recognizes SAML and OpenID Connect requests from the URL alone
+func ssoProtocol(u *url.URL) string {
+	query := u.Query()
+	path := strings.ToLower(u.Path)
+
+	if query.Has("SAMLRequest") || query.Has("SAMLResponse") || strings.Contains(path, "/saml") {
+		return "saml"
+	}
+	if strings.Contains(path, "/.well-known/openid-configuration") ||
+		strings.Contains(path, "/openid-connect/") ||
+		strings.Contains(" "+query.Get("scope")+" ", " openid ") {
+		return "oidc"
+	}
+	if query.Has("client_id") && query.Has("response_type") {
+		return "oauth2"
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestDetectSSO tests identity provider detection across element types
+func TestDetectSSO(t *testing.T) {
+	htmlStr := `
+		<html><head>
+			<script src="https://accounts.google.com/gsi/client"></script>
+		</head><body>
+			<a href="https://github.com/login/oauth/authorize?client_id=abc">Continue</a>
+			<button>Sign in with Apple</button>
+			<form action="https://idp.corp.example/sso/saml?SAMLRequest=xyz" method="post"></form>
+			<a href="https://auth.example.org/authorize?client_id=a&response_type=code&scope=openid%20email">Company SSO</a>
+			<a href="https://github.com/about">About GitHub</a>
+		</body></html>
+	`
+
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	require.NoError(t, err)
+
+	baseURL, _ := url.Parse("https://example.com/login")
+	result := detectSSO(doc, baseURL)
+
+	require.True(t, result.Detected)
+
+	protocols := make(map[string]string)
+	for _, p := range result.Providers {
+		protocols[p.Name] = p.Protocol
+		assert.NotEmpty(t, p.Evidence, "provider %s has no evidence", p.Name)
+	}
+
+	assert.Equal(t, map[string]string{
+		"Apple":            "oidc",
+		"GitHub":           "oauth2",
+		"Google":           "oidc",
+		"auth.example.org": "oidc",
+		"idp.corp.example": "saml",
+	}, protocols)
+}
+
+// TestDetectSSONone ensures ordinary pages report no providers
+func TestDetectSSONone(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><a href="/about">About</a><form action="/search"></form></body></html>`))
+	require.NoError(t, err)
+
+	baseURL, _ := url.Parse("https://example.com/")
+	result := detectSSO(doc, baseURL)
+
+	assert.False(t, result.Detected)
+	assert.Empty(t, result.Providers)
+}
+
+// TestSSOProtocol tests protocol recognition from URLs
+func TestSSOProtocol(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://idp.example.com/adfs/ls/?SAMLRequest=abc", "saml"},
+		{"https://sso.example.com/saml2/login", "saml"},
+		{"https://id.example.com/realms/main/protocol/openid-connect/auth", "oidc"},
+		{"https://id.example.com/authorize?scope=openid+profile", "oidc"},
+		{"https://id.example.com/authorize?client_id=a&response_type=code", "oauth2"},
+		{"https://example.com/about", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ssoProtocol(u))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package analyzer
+
+import "strings"
+
+// stopWords are the most frequent function words of each language. They
+// identify the language of a text and are left out of keyword counts.
+var stopWords = map[string]map[string]bool{
+	"en": toSet(strings.Fields(`
+		a about above after again against all also am an and any are as at be
+		because been before being below between both but by can could did do
+		does doing down during each few for from further had has have having he
+		her here hers herself him himself his how i if in into is it its itself
+		just me more most my myself no nor not now of off on once only or other
+		our ours ourselves out over own same she should so some such than that
+		the their theirs them themselves then there these they this those
+		through to too under until up very was we were what when where which
+		while who whom why will with would you your yours yourself yourselves`)...),
+	"de": toSet(strings.Fields(`
+		aber alle allem allen aller alles als also am an ander andere anderem
+		anderen anderer anderes auch auf aus bei bin bis bist da damit dann das
+		dass dein deine dem den der des dessen dich dir doch dort du durch ein
+		eine einem einen einer eines er es euer eure für gegen hat hatte hier
+		hin hinter ich ihm ihn ihnen ihr ihre im in indem ins ist jede jedem
+		jeden jeder jedes jetzt kann kein keine können man mein meine mich mir
+		mit muss nach nicht nichts noch nun nur ob oder ohne sehr sein seine
+		sich sie sind so soll sondern über um und uns unser unter viel vom von
+		vor war waren warum was weil welche wenn wer werden wie wieder will wir
+		wird wo zu zum zur zwischen`)...),
+	"fr": toSet(strings.Fields(`
+		à au aux avec ce ces cette comme dans de des du elle elles en est et eu
+		été être il ils je la le les leur leurs lui ma mais me même mes moi mon
+		ne nos notre nous on ont ou où par pas plus pour qu que qui sa sans se
+		ses son sont sur ta te tes toi ton tous tout très tu un une vos votre
+		vous y aussi avoir bien c ça d donc fait faire j l m n peut s si t
+		encore entre ici alors avant après depuis déjà`)...),
+	"es": toSet(strings.Fields(`
+		a al algo algunos ante antes como con contra cual cuando de del desde
+		donde durante e el él ella ellas ellos en entre era es esa esas ese eso
+		esos esta está están estas este esto estos fue fueron ha han hasta hay
+		la las le les lo los más me mi mis mucho muy nada ni no nos nosotros o
+		otra otros para pero poco por porque que quien se sea ser si sí sin
+		sobre su sus también tanto te tiene tienen todo todos tu tus un una uno
+		unos y ya yo`)...),
+	"it": toSet(strings.Fields(`
+		a ad al alla alle agli ai anche che chi ci come con cosa da dal dalla
+		dei del della delle dello di dove e è ed era gli ha hanno il in io la
+		le lei li lo loro lui ma mi mia mio molto ne nei nel nella noi non o
+		per perché più quale quando quello questa questo se sei si sia sono
+		su sua suo sue sui sul sulla tra tu tutti tutto un una uno voi anche
+		già stato essere fa fare`)...),
+	"pt": toSet(strings.Fields(`
+		a ao aos as até com como da das de dela dele do dos e é ela elas ele
+		eles em entre era essa esse esta está estão este eu foi foram há isso
+		isto já lhe mais mas me mesmo meu minha muito na não nas nem no nos
+		nós o os ou para pela pelo por qual quando que quem se sem ser seu sua
+		são também te tem têm um uma você vocês`)...),
+	"nl": toSet(strings.Fields(`
+		aan al alles als altijd andere ben bij daar dan dat de der deze die dit
+		doch doen door dus een eens en er ge geen geweest haar had heb hebben
+		heeft hem het hier hij hoe hun iemand iets ik in is ja je kan kon kunnen
+		maar me meer men met mij mijn moet na naar niet niets nog nu of om omdat
+		onder ons ook op over reeds te tegen toch toen tot u uit uw van veel
+		voor want waren was wat werd wezen wie wil worden wordt zal ze zelf zich
+		zij zijn zo zonder zou`)...),
+}
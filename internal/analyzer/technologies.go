@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/fingerprint"
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// jsGlobalPatterns find variables an inline script defines on the global object
+var jsGlobalPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:^|[^\w$.])(?:var|let|const)\s+([A-Za-z_$][\w$]*)\s*=`),
+	regexp.MustCompile(`\b(?:window|self|globalThis)\.([A-Za-z_$][\w$]*)\s*=`),
+	regexp.MustCompile(`\b(?:window|self|globalThis)\[\s*["']([A-Za-z_$][\w$]*)["']\s*\]`),
+	regexp.MustCompile(`(?:^|[^\w$.])function\s+([A-Za-z_$][\w$]*)\s*\(`),
+}
+
+// detectTechnologies runs the fingerprinting rules over the page response
+func detectTechnologies(engine *fingerprint.Engine, doc *html.Node, header http.Header, body []byte,
+	resources []models.Resource, cookies []models.CookieInfo) []models.Technology {
+	if engine == nil {
+		engine = fingerprint.Default()
+	}
+
+	input := fingerprint.Input{
+		Headers: header,
+		Meta:    make(map[string][]string),
+		HTML:    string(body),
+	}
+	for _, cookie := range cookies {
+		input.Cookies = append(input.Cookies, cookie.Name)
+	}
+	for _, r := range resources {
+		if r.Type == resourceScript {
+			input.ScriptSrc = append(input.ScriptSrc, r.URL)
+		}
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "meta":
+				if name := strings.ToLower(getAttr(n, "name")); name != "" {
+					input.Meta[name] = append(input.Meta[name], getAttr(n, "content"))
+				}
+			case "script":
+				if getAttr(n, "src") == "" && isJavaScriptType(getAttr(n, "type")) {
+					input.JSGlobals = append(input.JSGlobals, extractJSGlobals(textContent(n))...)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+
+	return engine.Detect(input)
+}
+
+// extractJSGlobals lists the global names assigned or declared by a script
+func extractJSGlobals(script string) []string {
+	var names []string
+	for _, re := range jsGlobalPatterns {
+		for _, match := range re.FindAllStringSubmatch(script, -1) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// isJavaScriptType reports whether a script type attribute denotes JavaScript
+// rather than a data block such as JSON-LD
+func isJavaScriptType(scriptType string) bool {
+	switch strings.ToLower(strings.TrimSpace(scriptType)) {
+	case "", "text/javascript", "application/javascript", "module", "text/ecmascript", "application/ecmascript":
+		return true
+	}
+	return false
+}
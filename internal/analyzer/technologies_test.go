@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestExtractJSGlobals tests global name extraction from inline scripts
+func TestExtractJSGlobals(t *testing.T) {
+	script := `
+		window.dataLayer = window.dataLayer || [];
+		function gtag(){dataLayer.push(arguments);}
+		var _paq = _paq || [];
+		window["__NUXT__"] = {};
+		obj.notGlobal = 1;
+	`
+	globals := extractJSGlobals(script)
+
+	assert.ElementsMatch(t, []string{"dataLayer", "gtag", "_paq", "__NUXT__"}, globals)
+}
+
+// TestDetectTechnologies tests fingerprint input gathered from a parsed page
+func TestDetectTechnologies(t *testing.T) {
+	body := `
+		<html><head>
+			<meta name="generator" content="Drupal 10 (https://www.drupal.org)">
+			<script src="/core/misc/drupal.js"></script>
+			<script type="application/ld+json">{"var x = 1": true}</script>
+			<script>var _paq = window._paq || [];</script>
+		</head><body></body></html>
+	`
+	doc, err := html.Parse(strings.NewReader(body))
+	require.NoError(t, err)
+
+	pageURL, _ := url.Parse("https://example.com/")
+	header := http.Header{}
+	header.Set("X-Powered-By", "PHP/8.2.1")
+
+	technologies := detectTechnologies(nil, doc, header, []byte(body), extractResources(doc, pageURL), nil)
+
+	versions := make(map[string]string)
+	for _, tech := range technologies {
+		versions[tech.Name] = tech.Version
+	}
+	assert.Equal(t, map[string]string{"Drupal": "10", "Matomo": "", "PHP": "8.2.1"}, versions)
+}
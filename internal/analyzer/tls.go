@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+// defaultCertExpiryWindow is how close to expiry a certificate gets flagged
+const defaultCertExpiryWindow = 30 * 24 * time.Hour
+
+// inspectTLS reports on the negotiated connection and the server certificate.
+// Chains rejected by the client never reach this point, unless the client
+// skips verification; the chain is then verified here against the client's roots.
+func inspectTLS(state *tls.ConnectionState, hostname string, client *http.Client, window time.Duration) *models.TLSReport {
+	if window <= 0 {
+		window = defaultCertExpiryWindow
+	}
+
+	report := &models.TLSReport{
+		Version:          tls.VersionName(state.Version),
+		CipherSuite:      tls.CipherSuiteName(state.CipherSuite),
+		ALPN:             state.NegotiatedProtocol,
+		Chain:            []models.CertificateInfo{},
+		ExpiryWindowDays: int(window.Hours() / 24),
+	}
+	if len(state.PeerCertificates) == 0 {
+		return report
+	}
+
+	leaf := state.PeerCertificates[0]
+	report.Certificate = certificateInfo(leaf)
+	report.HostnameMatch = leaf.VerifyHostname(hostname) == nil
+
+	remaining := time.Until(leaf.NotAfter)
+	report.DaysRemaining = int(math.Floor(remaining.Hours() / 24))
+	report.ExpiresSoon = remaining < window
+
+	chain := state.PeerCertificates
+	if len(state.VerifiedChains) > 0 {
+		report.ChainValid = true
+		chain = state.VerifiedChains[0]
+	} else {
+		intermediates := x509.NewCertPool()
+		for _, cert := range state.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		chains, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         clientRootCAs(client),
+			Intermediates: intermediates,
+		})
+		if err != nil {
+			report.VerificationError = err.Error()
+		} else {
+			report.ChainValid = true
+			chain = chains[0]
+		}
+	}
+
+	for _, cert := range chain {
+		report.Chain = append(report.Chain, certificateInfo(cert))
+	}
+
+	return report
+}
+
+func certificateInfo(cert *x509.Certificate) models.CertificateInfo {
+	return models.CertificateInfo{
+		Subject:   cert.Subject.String(),
+		Issuer:    cert.Issuer.String(),
+		DNSNames:  cert.DNSNames,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}
+}
+
+// clientRootCAs returns the root pool the client trusts, nil meaning the system pool
+func clientRootCAs(client *http.Client) *x509.CertPool {
+	if transport, ok := client.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		return transport.TLSClientConfig.RootCAs
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTLSTestServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<!DOCTYPE html><html><head><title>Secure</title></head><body></body></html>`))
+	}))
+}
+
+// TestAnalyzeTLS tests TLS inspection against a test server
+func TestAnalyzeTLS(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	analyzer := &Analyzer{client: server.Client()}
+	result, err := analyzer.Analyze(server.URL)
+	require.NoError(t, err)
+	require.NotNil(t, result.TLS)
+
+	report := result.TLS
+	assert.Contains(t, []string{"TLS 1.2", "TLS 1.3"}, report.Version)
+	assert.NotEmpty(t, report.CipherSuite)
+	assert.True(t, report.ChainValid)
+	assert.Empty(t, report.VerificationError)
+	assert.True(t, report.HostnameMatch, "test certificate covers 127.0.0.1")
+	assert.Contains(t, report.Certificate.DNSNames, "example.com")
+	assert.NotEmpty(t, report.Chain)
+	assert.Greater(t, report.DaysRemaining, 0)
+	assert.False(t, report.ExpiresSoon)
+	assert.Equal(t, 30, report.ExpiryWindowDays)
+}
+
+// TestAnalyzeTLSExpiryWindow tests the configurable expiry window
+func TestAnalyzeTLSExpiryWindow(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	// The test certificate is valid for decades, so only a very wide window flags it
+	analyzer := &Analyzer{
+		client: server.Client(),
+		config: Config{CertExpiryWindow: 200 * 365 * 24 * time.Hour},
+	}
+	result, err := analyzer.Analyze(server.URL)
+	require.NoError(t, err)
+	require.NotNil(t, result.TLS)
+	assert.True(t, result.TLS.ExpiresSoon)
+}
+
+// TestAnalyzeTLSUntrustedChain tests chain verification when the client skips it
+func TestAnalyzeTLSUntrustedChain(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	analyzer := &Analyzer{client: client}
+	result, err := analyzer.Analyze(server.URL)
+	require.NoError(t, err)
+	require.NotNil(t, result.TLS)
+	assert.False(t, result.TLS.ChainValid)
+	assert.NotEmpty(t, result.TLS.VerificationError)
+}
+
+// TestAnalyzePlainHTTPHasNoTLS ensures plain HTTP pages carry no TLS report
+func TestAnalyzePlainHTTPHasNoTLS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body></body></html>`))
+	}))
+	defer server.Close()
+
+	analyzer := &Analyzer{client: server.Client()}
+	result, err := analyzer.Analyze(server.URL)
+	require.NoError(t, err)
+	assert.Nil(t, result.TLS)
+}
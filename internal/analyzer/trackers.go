@@ -0,0 +1,290 @@
+package analyzer
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+const (
+	trackerAnalytics     = "analytics"
+	trackerAdvertising   = "advertising"
+	trackerSessionReplay = "session replay"
+	trackerTagManager    = "tag manager"
+)
+
+// trackerVendor describes how a tracking tag shows up in markup
+type trackerVendor struct {
+	name     string
+	category string
+	// endpoints are the URLs the tag loads from or reports to, see matchesEndpoint
+	endpoints []string
+	// snippets are substrings that identify the vendor's inline loader
+	snippets []string
+	// ids extract property IDs from URLs and inline snippets; the first
+	// capture group is the ID
+	ids []*regexp.Regexp
+}
+
+var trackerVendors = []trackerVendor{
+	{
+		name: "Google Analytics", category: trackerAnalytics,
+		endpoints: []string{"google-analytics.com", "googletagmanager.com/gtag/js", "analytics.google.com"},
+		snippets:  []string{"gtag(", "google-analytics.com", "GoogleAnalyticsObject"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`\b(UA-\d{4,10}-\d{1,4})\b`),
+			regexp.MustCompile(`\b(G-[A-Z0-9]{6,12})\b`),
+		},
+	},
+	{
+		name: "Google Tag Manager", category: trackerTagManager,
+		endpoints: []string{"googletagmanager.com/gtm.js", "googletagmanager.com/ns.html"},
+		snippets:  []string{"googletagmanager.com/gtm.js"},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`\b(GTM-[A-Z0-9]{4,9})\b`)},
+	},
+	{
+		name: "Google Ads", category: trackerAdvertising,
+		endpoints: []string{"googleadservices.com", "doubleclick.net", "googlesyndication.com"},
+		snippets:  []string{"adsbygoogle"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`\b(AW-\d{6,12})\b`),
+			regexp.MustCompile(`\b(ca-pub-\d{10,20})\b`),
+		},
+	},
+	{
+		name: "Meta Pixel", category: trackerAdvertising,
+		endpoints: []string{"connect.facebook.net/fbevents.js", "facebook.com/tr"},
+		snippets:  []string{"fbq(", "connect.facebook.net"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`fbq\(\s*['"]init['"]\s*,\s*['"](\d{10,20})['"]`),
+			regexp.MustCompile(`facebook\.com/tr/?\?(?:[^"'\s]*&)?id=(\d{10,20})`),
+		},
+	},
+	{
+		name: "LinkedIn Insight Tag", category: trackerAdvertising,
+		endpoints: []string{"snap.licdn.com", "px.ads.linkedin.com"},
+		snippets:  []string{"_linkedin_partner_id"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`_linkedin_partner_id\s*=\s*['"]?(\d+)`),
+			regexp.MustCompile(`px\.ads\.linkedin\.com/collect/?\?(?:[^"'\s]*&)?pid=(\d+)`),
+		},
+	},
+	{
+		name: "TikTok Pixel", category: trackerAdvertising,
+		endpoints: []string{"analytics.tiktok.com"},
+		snippets:  []string{"ttq.load("},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`ttq\.load\(\s*['"]([A-Z0-9]{10,30})['"]`)},
+	},
+	{
+		name: "X Pixel", category: trackerAdvertising,
+		endpoints: []string{"static.ads-twitter.com", "analytics.twitter.com", "t.co/i/adsct"},
+		snippets:  []string{"twq("},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`twq\(\s*['"](?:init|config)['"]\s*,\s*['"]([a-z0-9]{4,10})['"]`)},
+	},
+	{
+		name: "Microsoft Advertising", category: trackerAdvertising,
+		endpoints: []string{"bat.bing.com"},
+		snippets:  []string{"bat.bing.com"},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`\bti\s*:\s*['"](\d{5,12})['"]`)},
+	},
+	{
+		name: "Criteo", category: trackerAdvertising,
+		endpoints: []string{"static.criteo.net", "dis.criteo.com"},
+		snippets:  []string{"criteo_q"},
+	},
+	{
+		name: "Hotjar", category: trackerSessionReplay,
+		endpoints: []string{"static.hotjar.com", "script.hotjar.com"},
+		snippets:  []string{"_hjSettings", "static.hotjar.com"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`hjid\s*:\s*(\d+)`),
+			regexp.MustCompile(`hotjar-(\d+)\.js`),
+		},
+	},
+	{
+		name: "Microsoft Clarity", category: trackerSessionReplay,
+		endpoints: []string{"clarity.ms"},
+		snippets:  []string{"clarity.ms/tag/"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`clarity\.ms/tag/([a-z0-9]{6,12})`),
+			regexp.MustCompile(`['"]clarity['"]\s*,\s*['"]script['"]\s*,\s*['"]([a-z0-9]{6,12})['"]`),
+		},
+	},
+	{
+		name: "FullStory", category: trackerSessionReplay,
+		endpoints: []string{"fullstory.com"},
+		snippets:  []string{"_fs_org"},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`_fs_org['"]?\]?\s*=\s*['"]([A-Za-z0-9-]{4,20})['"]`)},
+	},
+	{
+		name: "Segment", category: trackerAnalytics,
+		endpoints: []string{"cdn.segment.com", "api.segment.io"},
+		snippets:  []string{"analytics.load(", "cdn.segment.com"},
+		ids: []*regexp.Regexp{
+			regexp.MustCompile(`analytics\.load\(\s*['"]([A-Za-z0-9]{10,40})['"]`),
+			regexp.MustCompile(`analytics\.js/v1/([A-Za-z0-9]{10,40})/`),
+		},
+	},
+	{
+		name: "Mixpanel", category: trackerAnalytics,
+		endpoints: []string{"cdn.mxpnl.com", "api-js.mixpanel.com", "cdn.mixpanel.com"},
+		snippets:  []string{"mixpanel.init("},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`mixpanel\.init\(\s*['"]([a-f0-9]{32})['"]`)},
+	},
+	{
+		name: "Amplitude", category: trackerAnalytics,
+		endpoints: []string{"cdn.amplitude.com", "api2.amplitude.com"},
+		snippets:  []string{"amplitude.getInstance()", "amplitude.init("},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`amplitude(?:\.getInstance\(\))?\.init\(\s*['"]([a-f0-9]{32})['"]`)},
+	},
+	{
+		name: "Heap", category: trackerAnalytics,
+		endpoints: []string{"cdn.heapanalytics.com", "heapanalytics.com"},
+		snippets:  []string{"heap.load("},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`heap\.load\(\s*['"](\d{6,12})['"]`)},
+	},
+	{
+		name: "Matomo", category: trackerAnalytics,
+		endpoints: []string{"matomo.cloud", "innocraft.cloud"},
+		snippets:  []string{"_paq.push("},
+		ids:       []*regexp.Regexp{regexp.MustCompile(`setSiteId['"]\s*,\s*['"]?(\d+)`)},
+	},
+	{
+		name: "Plausible", category: trackerAnalytics,
+		endpoints: []string{"plausible.io/js"},
+	},
+}
+
+// beaconURLPattern finds absolute URLs in text the parser does not turn into
+// elements, such as the <img> beacons inside <noscript>
+var beaconURLPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// detectTrackers inventories the tracking tags loaded by script sources,
+// inline snippets, image beacons and iframes, and lists the third-party
+// domains contacted by the page's subresources
+func detectTrackers(doc *html.Node, baseURL *url.URL, resources []models.Resource) models.TrackerReport {
+	trackers := make(map[string]*models.Tracker)
+	pageHost := strings.ToLower(baseURL.Hostname())
+	domains := make(map[string]bool)
+
+	record := func(vendor trackerVendor, source, text string) {
+		t, ok := trackers[vendor.name]
+		if !ok {
+			t = &models.Tracker{
+				Vendor:      vendor.name,
+				Category:    vendor.category,
+				PropertyIDs: []string{},
+				Sources:     []string{},
+			}
+			trackers[vendor.name] = t
+		}
+		if !containsString(t.Sources, source) {
+			t.Sources = append(t.Sources, source)
+		}
+		for _, re := range vendor.ids {
+			for _, match := range re.FindAllStringSubmatch(text, -1) {
+				if !containsString(t.PropertyIDs, match[1]) {
+					t.PropertyIDs = append(t.PropertyIDs, match[1])
+				}
+			}
+		}
+	}
+
+	inspectURL := func(kind, raw string) {
+		u, err := baseURL.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return
+		}
+		if host := strings.ToLower(u.Hostname()); !isFirstPartyHost(host, pageHost) {
+			domains[host] = true
+		}
+		for _, vendor := range trackerVendors {
+			if matchesEndpoint(vendor.endpoints, u) {
+				record(vendor, kind+":"+u.String(), u.String())
+				return
+			}
+		}
+	}
+
+	inspectSnippet := func(script string) {
+		for _, vendor := range trackerVendors {
+			for _, snippet := range vendor.snippets {
+				if strings.Contains(script, snippet) {
+					record(vendor, "inline", script)
+					break
+				}
+			}
+		}
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script":
+				if src := getAttr(n, "src"); src != "" {
+					inspectURL("script", src)
+				} else if isJavaScriptType(getAttr(n, "type")) {
+					inspectSnippet(textContent(n))
+				}
+			case "img":
+				if src := getAttr(n, "src"); src != "" {
+					inspectURL("image", src)
+				}
+			case "iframe":
+				if src := getAttr(n, "src"); src != "" {
+					inspectURL("iframe", src)
+				}
+			case "noscript":
+				for _, raw := range beaconURLPattern.FindAllString(textContent(n), -1) {
+					inspectURL("noscript", html.UnescapeString(raw))
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			crawler(c)
+		}
+	}
+	crawler(doc)
+
+	for _, r := range resources {
+		if r.ThirdParty {
+			domains[r.Host] = true
+		}
+	}
+
+	report := models.TrackerReport{
+		ByCategory:        make(map[string]int),
+		Trackers:          []models.Tracker{},
+		ThirdPartyDomains: []string{},
+	}
+	for _, t := range trackers {
+		sort.Strings(t.PropertyIDs)
+		report.Trackers = append(report.Trackers, *t)
+		report.ByCategory[t.Category]++
+	}
+	sort.Slice(report.Trackers, func(i, j int) bool {
+		return report.Trackers[i].Vendor < report.Trackers[j].Vendor
+	})
+	for domain := range domains {
+		report.ThirdPartyDomains = append(report.ThirdPartyDomains, domain)
+	}
+	sort.Strings(report.ThirdPartyDomains)
+	report.Count = len(report.Trackers)
+
+	return report
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+// TestDetectTrackers tests tracker detection across script sources, inline
+// snippets, image beacons and iframes
+func TestDetectTrackers(t *testing.T) {
+	body := `
+		<html><head>
+			<script async src="https://www.googletagmanager.com/gtag/js?id=G-ABC123XYZ"></script>
+			<script>
+				window.dataLayer = window.dataLayer || [];
+				function gtag(){dataLayer.push(arguments);}
+				gtag('config', 'G-ABC123XYZ');
+				gtag('config', 'UA-1234567-2');
+			</script>
+			<script>
+				!function(f,b,e,v,n,t,s){}(window, document,'script','https://connect.facebook.net/en_US/fbevents.js');
+				fbq('init', '123456789012345');
+			</script>
+			<script>
+				(function(h,o,t,j,a,r){h._hjSettings={hjid:3141592,hjsv:6};})(window,document,'https://static.hotjar.com/c/hotjar-','.js?sv=');
+			</script>
+			<script type="application/ld+json">{"note": "analytics.load('notatracker12345')"}</script>
+			<script src="/js/app.js"></script>
+		</head><body>
+			<noscript><img height="1" width="1" src="https://www.facebook.com/tr?id=123456789012345&amp;ev=PageView&amp;noscript=1"/></noscript>
+			<noscript><iframe src="https://www.googletagmanager.com/ns.html?id=GTM-W2X9KQ"></iframe></noscript>
+			<img src="https://cdn.segment.com/analytics.js/v1/abcDEF123456ghij/analytics.min.js">
+			<iframe src="https://www.youtube.com/embed/xyz"></iframe>
+		</body></html>
+	`
+	doc, err := html.Parse(strings.NewReader(body))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://www.example.com/")
+
+	report := detectTrackers(doc, baseURL, extractResources(doc, baseURL))
+
+	vendors := make(map[string][]string)
+	categories := make(map[string]string)
+	for _, tracker := range report.Trackers {
+		vendors[tracker.Vendor] = tracker.PropertyIDs
+		categories[tracker.Vendor] = tracker.Category
+		assert.NotEmpty(t, tracker.Sources, tracker.Vendor)
+	}
+
+	assert.Equal(t, []string{"G-ABC123XYZ", "UA-1234567-2"}, vendors["Google Analytics"])
+	assert.Equal(t, []string{"GTM-W2X9KQ"}, vendors["Google Tag Manager"])
+	assert.Equal(t, []string{"123456789012345"}, vendors["Meta Pixel"])
+	assert.Equal(t, []string{"3141592"}, vendors["Hotjar"])
+	assert.Equal(t, []string{"abcDEF123456ghij"}, vendors["Segment"])
+	assert.Equal(t, 5, report.Count)
+
+	assert.Equal(t, "advertising", categories["Meta Pixel"])
+	assert.Equal(t, "session replay", categories["Hotjar"])
+	assert.Equal(t, 2, report.ByCategory["analytics"])
+
+	assert.Equal(t, []string{
+		"cdn.segment.com",
+		"www.facebook.com",
+		"www.googletagmanager.com",
+		"www.youtube.com",
+	}, report.ThirdPartyDomains)
+}
+
+// TestDetectTrackersNone tests a page without any trackers
+func TestDetectTrackersNone(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><script src="/app.js"></script><img src="https://static.example.com/logo.png"></body></html>`))
+	require.NoError(t, err)
+	baseURL, _ := url.Parse("https://www.example.com/")
+
+	report := detectTrackers(doc, baseURL, extractResources(doc, baseURL))
+
+	assert.Equal(t, 0, report.Count)
+	assert.Empty(t, report.Trackers)
+	assert.Empty(t, report.ThirdPartyDomains)
+}
@@ -11,9 +11,12 @@ import (
 // toggle flag -> enable/disable caching
 var EnableCaching = true
 
+// AnalyzerConfig configures the analyzer used by DefaultAnalyzer
+var AnalyzerConfig = analyzer.DefaultConfig()
+
 // Analyzer interface defines the behavior for a web page analyzer
 type Analyzer interface {
-	Analyze(url string) (*models.AnalysisResponse, error)
+	Analyze(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error)
 }
 
 // Global singleton
@@ -39,10 +42,10 @@ func GetAnalyzer() Analyzer {
 type DefaultAnalyzer struct{}
 
 // Analyze implements the Analyzer interface by calling the actual analyzer
-func (da *DefaultAnalyzer) Analyze(url string) (*models.AnalysisResponse, error) {
+func (da *DefaultAnalyzer) Analyze(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
 	// Create an instance of actual analyzer
-	realAnalyzer := analyzer.NewAnalyzer()
+	realAnalyzer := analyzer.NewAnalyzerWithConfig(AnalyzerConfig)
 
 	// Call the actual analyze method
-	return realAnalyzer.Analyze(url)
+	return realAnalyzer.AnalyzeWithOptions(url, options)
 }
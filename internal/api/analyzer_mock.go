@@ -6,10 +6,10 @@ import (
 
 // MockAnalyzer is a test implementation of the Analyzer interface
 type MockAnalyzer struct {
-    AnalyzeFn func(url string) (*models.AnalysisResponse, error)
+    AnalyzeFn func(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error)
 }
 
 // Analyze calls the mock implementation function
-func (m *MockAnalyzer) Analyze(url string) (*models.AnalysisResponse, error) {
-    return m.AnalyzeFn(url)
+func (m *MockAnalyzer) Analyze(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
+    return m.AnalyzeFn(url, options)
 }
\ No newline at end of file
@@ -2,11 +2,13 @@ package api
 
 import (
 	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/maheshjq/web-analyzer_v1/internal/metrics"
 	"github.com/maheshjq/web-analyzer_v1/internal/models"
+	"github.com/maheshjq/web-analyzer_v1/internal/urlnorm"
 )
 
 type CachedResult struct {
@@ -43,13 +45,35 @@ func (ca *CachedAnalyzer) cleanupCache(interval time.Duration) {
 	}
 }
 
+// normalizeURL returns the single spelling analyzed and cached for the
+// spellings of the same page, such as https://Example.com and
+// https://example.com/#top
+func normalizeURL(url string) string {
+	normalized, err := urlnorm.Normalize(url)
+	if err != nil {
+		return url
+	}
+	return normalized
+}
+
+// cacheKey normalizes a URL so that spellings of the same page share one
+// cache entry. Analyses for different target keywords are cached separately.
+func cacheKey(url string, options models.AnalysisOptions) string {
+	key := normalizeURL(url)
+	if keyword := strings.Join(strings.Fields(strings.ToLower(options.TargetKeyword)), " "); keyword != "" {
+		key += "\n" + keyword
+	}
+	return key
+}
+
 // Analyze implements the Analyzer interface with caching
-func (ca *CachedAnalyzer) Analyze(url string) (*models.AnalysisResponse, error) {
+func (ca *CachedAnalyzer) Analyze(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
 	start := time.Now()
+	key := cacheKey(url, options)
 
 	// Check cache first
 	ca.mu.RLock()
-	cached, found := ca.cache[url]
+	cached, found := ca.cache[key]
 	ca.mu.RUnlock()
 
 	now := time.Now()
@@ -80,7 +104,9 @@ func (ca *CachedAnalyzer) Analyze(url string) (*models.AnalysisResponse, error)
 
 	metrics.AnalysisCount.Inc()
 
-	result, err := ca.delegate.Analyze(url)
+	// The normalized URL is analyzed so that the URL-specific parts of the
+	// result hold for every spelling that shares the cache entry
+	result, err := ca.delegate.Analyze(normalizeURL(url), options)
 	analysisDuration := time.Since(analysisStart)
 
 	metrics.AnalysisDuration.Observe(analysisDuration.Seconds())
@@ -95,7 +121,7 @@ func (ca *CachedAnalyzer) Analyze(url string) (*models.AnalysisResponse, error)
 
 	// Store in cache
 	ca.mu.Lock()
-	ca.cache[url] = CachedResult{
+	ca.cache[key] = CachedResult{
 		Result:    result,
 		ExpiresAt: now.Add(ca.ttl),
 	}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+func TestCachedAnalyzerNormalizesKeys(t *testing.T) {
+	var analyzed []string
+	mock := &MockAnalyzer{
+		AnalyzeFn: func(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
+			analyzed = append(analyzed, url)
+			return &models.AnalysisResponse{Title: url}, nil
+		},
+	}
+	cached := NewCachedAnalyzer(mock, time.Minute)
+
+	for _, url := range []string{
+		"https://Example.com",
+		"https://example.com/",
+		"https://example.com:443/?utm_source=x",
+		"https://example.com#top",
+	} {
+		result, err := cached.Analyze(url, models.AnalysisOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/", result.Title)
+	}
+
+	_, err := cached.Analyze("https://example.com/about", models.AnalysisOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://example.com/", "https://example.com/about"}, analyzed)
+	assert.Equal(t, 3, cached.CacheHits())
+	assert.Equal(t, 2, cached.CacheMisses())
+}
+
+func TestCachedAnalyzerSeparatesTargetKeywords(t *testing.T) {
+	var keywords []string
+	mock := &MockAnalyzer{
+		AnalyzeFn: func(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
+			keywords = append(keywords, options.TargetKeyword)
+			return &models.AnalysisResponse{}, nil
+		},
+	}
+	cached := NewCachedAnalyzer(mock, time.Minute)
+
+	for _, keyword := range []string{"", "tomato seeds", "Tomato  Seeds", "", "potatoes"} {
+		_, err := cached.Analyze("https://example.com/", models.AnalysisOptions{TargetKeyword: keyword})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"", "tomato seeds", "potatoes"}, keywords)
+	assert.Equal(t, 2, cached.CacheHits())
+}
@@ -16,21 +16,42 @@ import (
 // It takes a JSON body with a URL and digs into the web page to pull out useful details about its structure and content.
 //
 // @Summary Analyze a web page
-// @Description
+// @Description Fetches and analyzes a web page by URL, returning information about its structure and content
 // This endpoint analyzes a web page based on the URL you provide and returns a breakdown of its key features, including:
-// - HTML version (like HTML5 or XHTML 1.0)
+// - HTML version (like HTML5 or XHTML 1.0), DOCTYPE identifiers and rendering mode
 // - Page title
 // - Counts of headings (h1 through h6)
 // - Link analysis:
 //   - Number of internal links
 //   - Number of external links
 //   - Number of inaccessible links
+//   - Fragment links with no matching anchor on their target page
+//   - Link markup findings (rel values, unsafe target=_blank, anchor text, duplicates)
+//   - Links by scheme category, with mailto and tel syntax validation
+//   - Links by host relation: same host, same site or cross-site
 //
-// - Whether there's a login form on the page
+// - Whether there's a login form on the page, with a confidence score and evidence
+// - Single sign-on providers offered on the page
+// - Subresource inventory grouped by type and origin, with page weight
+// - Mixed content on HTTPS pages
+// - Graded audit of the HTTP security headers
+// - TLS version, cipher and certificate details for HTTPS pages
+// - Cookies set by the page and its redirects, with attribute findings
+// - Technologies (CMS, frameworks, analytics, CDNs) the page is built with
+// - Third-party trackers with their property IDs and the domains contacted
+// - Consent management platform or banner, and trackers loaded without one
+// - Redacted secrets and sensitive data exposed in the page source
+// - Subresource Integrity of external scripts and stylesheets
+// - Markup conformance findings with their line and column
+// - Weighted SEO score with a pass, warn or fail verdict per rule
+// - Canonical and hreflang validation, including reciprocal alternates
+// - Main content text statistics, language and readability scores
+// - Top keywords and phrases, and where the optional target keyword appears
+// - Mobile readiness: viewport, responsive images, wide elements and tap targets
 // @Tags analysis
 // @Accept json
 // @Produce json
-// @Param request body models.AnalysisRequest true "URL to analyze"
+// @Param request body models.AnalysisRequest true "URL to analyze and an optional target keyword"
 // @Success 200 {object} models.AnalysisResponse "Successful analysis"
 // @Failure 400 {object} models.ErrorResponse "Invalid URL format or missing URL"
 // @Failure 502 {object} models.ErrorResponse "Unable to fetch the URL or an error occurred during analysis"
@@ -63,7 +84,9 @@ func AnalyzeHandler(w http.ResponseWriter, r *http.Request) {
 	analyzerInstance := GetAnalyzer()
 
 	// Analyze the url here
-	analysisResult, err := analyzerInstance.Analyze(req.URL)
+	analysisResult, err := analyzerInstance.Analyze(req.URL, models.AnalysisOptions{
+		TargetKeyword: req.TargetKeyword,
+	})
 	if err != nil {
 		log.Printf("Error analyzing URL %s: %v", req.URL, err)
 		sendErrorResponse(w, http.StatusBadGateway, fmt.Sprintf("Failed to analyze URL: %v", err))
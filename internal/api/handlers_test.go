@@ -13,12 +13,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var mockAnalyzeFunc func(url string) (*models.AnalysisResponse, error)
+var mockAnalyzeFunc func(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error)
 
 type testMockAnalyzer struct{}
 
-func (m *testMockAnalyzer) Analyze(url string) (*models.AnalysisResponse, error) {
-	return mockAnalyzeFunc(url)
+func (m *testMockAnalyzer) Analyze(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
+	return mockAnalyzeFunc(url, options)
 }
 
 func TestAnalyzeHandler(t *testing.T) {
@@ -35,7 +35,7 @@ func TestAnalyzeHandler(t *testing.T) {
 		ContainsLoginForm: false,
 	}
 
-	mockAnalyzeFunc = func(url string) (*models.AnalysisResponse, error) {
+	mockAnalyzeFunc = func(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
 		return mockResponse, nil
 	}
 
@@ -122,7 +122,7 @@ func TestAnalyzeHandler_AnalyzerError(t *testing.T) {
 
 	// Set up mock analyzer -> returns an error
 	singletonAnalyzer = &MockAnalyzer{
-		AnalyzeFn: func(url string) (*models.AnalysisResponse, error) {
+		AnalyzeFn: func(url string, options models.AnalysisOptions) (*models.AnalysisResponse, error) {
 			return nil, errors.New("analyzer error")
 		},
 	}
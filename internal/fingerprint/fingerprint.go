@@ -0,0 +1,367 @@
+// Package fingerprint detects the technologies a web page is built with from
+// rules that match response headers, cookies, meta tags, script URLs, markup
+// and JavaScript globals.
+//
+// Rules follow the Wappalyzer conventions: every pattern is a regular
+// expression, matched case-insensitively, that may carry tags such as
+// `\;version:\1` (take the version from the first capture group) or
+// `\;confidence:50` (the match only adds 50 to the confidence).
+package fingerprint
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+//go:embed rules.json
+var embeddedRules []byte
+
+// Input is everything the rules can match against
+type Input struct {
+	Headers   http.Header
+	Cookies   []string
+	Meta      map[string][]string
+	ScriptSrc []string
+	HTML      string
+	JSGlobals []string
+}
+
+// Rule is a technology definition as stored in the rules file
+type Rule struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"category"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Cookies   map[string]string `json:"cookies,omitempty"`
+	Meta      map[string]string `json:"meta,omitempty"`
+	ScriptSrc []string          `json:"scriptSrc,omitempty"`
+	HTML      []string          `json:"html,omitempty"`
+	JS        []string          `json:"js,omitempty"`
+	Implies   []string          `json:"implies,omitempty"`
+}
+
+type ruleFile struct {
+	Technologies []Rule `json:"technologies"`
+}
+
+// pattern is a compiled rule pattern with its tags
+type pattern struct {
+	re         *regexp.Regexp
+	version    string
+	confidence int
+}
+
+type technology struct {
+	Rule
+	headers   map[string]*pattern
+	cookies   map[string]*pattern
+	meta      map[string]*pattern
+	scriptSrc []*pattern
+	html      []*pattern
+	js        map[string]*pattern
+}
+
+// Engine matches pages against a compiled rule set
+type Engine struct {
+	technologies []*technology
+	byName       map[string]*technology
+}
+
+var (
+	defaultEngine    *Engine
+	defaultEngineErr error
+	defaultOnce      sync.Once
+)
+
+// Default returns the engine built from the embedded rules
+func Default() *Engine {
+	defaultOnce.Do(func() {
+		defaultEngine, defaultEngineErr = Parse(embeddedRules)
+	})
+	if defaultEngineErr != nil {
+		panic(fmt.Sprintf("invalid embedded fingerprint rules: %v", defaultEngineErr))
+	}
+	return defaultEngine
+}
+
+// LoadFile builds an engine from the embedded rules overridden by the rules
+// in the given file. A rule in the file replaces the embedded rule of the
+// same name; rules with new names are added.
+func LoadFile(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fingerprint rules: %w", err)
+	}
+
+	var base, override ruleFile
+	if err := json.Unmarshal(embeddedRules, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded fingerprint rules: %w", err)
+	}
+	if err := json.Unmarshal(data, &override); err != nil {
+		return nil, fmt.Errorf("failed to parse fingerprint rules %s: %w", path, err)
+	}
+
+	index := make(map[string]int)
+	for i, rule := range base.Technologies {
+		index[rule.Name] = i
+	}
+	for _, rule := range override.Technologies {
+		if i, ok := index[rule.Name]; ok {
+			base.Technologies[i] = rule
+		} else {
+			index[rule.Name] = len(base.Technologies)
+			base.Technologies = append(base.Technologies, rule)
+		}
+	}
+
+	return compile(base.Technologies)
+}
+
+// Parse builds an engine from a JSON rules document
+func Parse(data []byte) (*Engine, error) {
+	var file ruleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse fingerprint rules: %w", err)
+	}
+	return compile(file.Technologies)
+}
+
+func compile(rules []Rule) (*Engine, error) {
+	engine := &Engine{byName: make(map[string]*technology)}
+
+	compileMap := func(name string, raw map[string]string) (map[string]*pattern, error) {
+		compiled := make(map[string]*pattern, len(raw))
+		for key, value := range raw {
+			p, err := compilePattern(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			compiled[strings.ToLower(key)] = p
+		}
+		return compiled, nil
+	}
+	compileList := func(name string, raw []string) ([]*pattern, error) {
+		compiled := make([]*pattern, 0, len(raw))
+		for _, value := range raw {
+			p, err := compilePattern(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			compiled = append(compiled, p)
+		}
+		return compiled, nil
+	}
+
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("fingerprint rule without a name")
+		}
+
+		tech := &technology{Rule: rule}
+		var err error
+		if tech.headers, err = compileMap(rule.Name, rule.Headers); err != nil {
+			return nil, err
+		}
+		if tech.cookies, err = compileMap(rule.Name, rule.Cookies); err != nil {
+			return nil, err
+		}
+		if tech.meta, err = compileMap(rule.Name, rule.Meta); err != nil {
+			return nil, err
+		}
+		if tech.scriptSrc, err = compileList(rule.Name, rule.ScriptSrc); err != nil {
+			return nil, err
+		}
+		if tech.html, err = compileList(rule.Name, rule.HTML); err != nil {
+			return nil, err
+		}
+		// Globals are matched by name, so only the tags of a JS entry are compiled
+		tech.js = make(map[string]*pattern, len(rule.JS))
+		for _, entry := range rule.JS {
+			name, tags, _ := strings.Cut(entry, `\;`)
+			if tech.js[name], err = compilePattern(`\;` + tags); err != nil {
+				return nil, fmt.Errorf("%s: %w", rule.Name, err)
+			}
+		}
+
+		engine.technologies = append(engine.technologies, tech)
+		engine.byName[rule.Name] = tech
+	}
+
+	return engine, nil
+}
+
+// compilePattern parses a pattern with its optional `\;version:` and
+// `\;confidence:` tags. An empty pattern matches any value.
+func compilePattern(raw string) (*pattern, error) {
+	parts := strings.Split(raw, `\;`)
+	re, err := regexp.Compile("(?i)" + parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", parts[0], err)
+	}
+
+	p := &pattern{re: re, confidence: 100}
+	for _, tag := range parts[1:] {
+		key, value, _ := strings.Cut(tag, ":")
+		switch key {
+		case "version":
+			p.version = value
+		case "confidence":
+			confidence, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid confidence %q: %w", value, err)
+			}
+			p.confidence = confidence
+		}
+	}
+	return p, nil
+}
+
+// match reports whether the pattern matches and the version it extracted
+func (p *pattern) match(value string) (bool, string) {
+	groups := p.re.FindStringSubmatch(value)
+	if groups == nil {
+		return false, ""
+	}
+
+	version := p.version
+	for i := len(groups) - 1; i >= 1; i-- {
+		version = strings.ReplaceAll(version, `\`+strconv.Itoa(i), groups[i])
+	}
+	return true, strings.TrimSpace(version)
+}
+
+// detection accumulates the matches of one technology
+type detection struct {
+	confidence int
+	version    string
+	evidence   []string
+}
+
+func (d *detection) add(p *pattern, version, evidence string) {
+	d.confidence += p.confidence
+	if version != "" && len(version) > len(d.version) {
+		d.version = version
+	}
+	d.evidence = append(d.evidence, evidence)
+}
+
+// Detect returns the technologies matched by the input, sorted by name
+func (e *Engine) Detect(in Input) []models.Technology {
+	detections := make(map[string]*detection)
+	get := func(name string) *detection {
+		d, ok := detections[name]
+		if !ok {
+			d = &detection{}
+			detections[name] = d
+		}
+		return d
+	}
+
+	cookies := make(map[string]bool)
+	for _, name := range in.Cookies {
+		cookies[strings.ToLower(name)] = true
+	}
+	globals := make(map[string]bool)
+	for _, name := range in.JSGlobals {
+		globals[name] = true
+	}
+
+	for _, tech := range e.technologies {
+		for name, p := range tech.headers {
+			for _, value := range in.Headers.Values(name) {
+				if ok, version := p.match(value); ok {
+					get(tech.Name).add(p, version, "header:"+http.CanonicalHeaderKey(name))
+					break
+				}
+			}
+		}
+		for name, p := range tech.cookies {
+			// Cookie rules match on the name only, values are never collected
+			if cookies[name] {
+				get(tech.Name).add(p, "", "cookie:"+name)
+			}
+		}
+		for name, p := range tech.meta {
+			for _, content := range in.Meta[name] {
+				if ok, version := p.match(content); ok {
+					get(tech.Name).add(p, version, "meta:"+name)
+					break
+				}
+			}
+		}
+		for _, p := range tech.scriptSrc {
+			for _, src := range in.ScriptSrc {
+				if ok, version := p.match(src); ok {
+					get(tech.Name).add(p, version, "scriptSrc:"+src)
+					break
+				}
+			}
+		}
+		for _, p := range tech.html {
+			if ok, version := p.match(in.HTML); ok {
+				get(tech.Name).add(p, version, "html")
+			}
+		}
+		for name, p := range tech.js {
+			if globals[name] {
+				get(tech.Name).add(p, "", "js:"+name)
+			}
+		}
+	}
+
+	// Implied technologies inherit the confidence of the one implying them
+	var imply func(name string, confidence int, via string)
+	imply = func(name string, confidence int, via string) {
+		tech, ok := e.byName[name]
+		if !ok {
+			return
+		}
+		for _, implied := range tech.Implies {
+			d := get(implied)
+			if d.confidence >= confidence {
+				continue
+			}
+			d.confidence = confidence
+			d.evidence = append(d.evidence, "implied:"+via)
+			imply(implied, confidence, implied)
+		}
+	}
+	matched := make([]string, 0, len(detections))
+	for name := range detections {
+		matched = append(matched, name)
+	}
+	sort.Strings(matched)
+	for _, name := range matched {
+		imply(name, min(detections[name].confidence, 100), name)
+	}
+
+	technologies := []models.Technology{}
+	for name, d := range detections {
+		tech, ok := e.byName[name]
+		if !ok {
+			continue
+		}
+		sort.Strings(d.evidence)
+		technologies = append(technologies, models.Technology{
+			Name:       name,
+			Category:   tech.Category,
+			Version:    d.version,
+			Confidence: min(d.confidence, 100),
+			Evidence:   d.evidence,
+		})
+	}
+	sort.Slice(technologies, func(i, j int) bool {
+		return technologies[i].Name < technologies[j].Name
+	})
+
+	return technologies
+}
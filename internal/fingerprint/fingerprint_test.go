@@ -0,0 +1,116 @@
+package fingerprint
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+func byName(technologies []models.Technology) map[string]models.Technology {
+	found := make(map[string]models.Technology)
+	for _, tech := range technologies {
+		found[tech.Name] = tech
+	}
+	return found
+}
+
+// TestEmbeddedRulesCompile ensures the embedded rule set is valid
+func TestEmbeddedRulesCompile(t *testing.T) {
+	_, err := Parse(embeddedRules)
+	require.NoError(t, err)
+	assert.NotPanics(t, func() { Default() })
+}
+
+// TestDetect tests matching on every input kind, versions and implied technologies
+func TestDetect(t *testing.T) {
+	header := http.Header{}
+	header.Set("Server", "nginx/1.25.3")
+	header.Set("CF-RAY", "8a1b2c3d4e5f-AMS")
+
+	technologies := Default().Detect(Input{
+		Headers:   header,
+		Cookies:   []string{"_ga"},
+		Meta:      map[string][]string{"generator": {"WordPress 6.4.2"}},
+		ScriptSrc: []string{"https://example.com/wp-includes/js/jquery/jquery.min.js?ver=3.7.1"},
+		HTML:      `<html><head><link rel="stylesheet" href="/wp-content/themes/x/style.css"></head></html>`,
+		JSGlobals: []string{"gtag"},
+	})
+	found := byName(technologies)
+
+	require.Contains(t, found, "WordPress")
+	assert.Equal(t, "6.4.2", found["WordPress"].Version)
+	assert.Equal(t, 100, found["WordPress"].Confidence)
+	assert.Contains(t, found["WordPress"].Evidence, "meta:generator")
+
+	require.Contains(t, found, "Nginx")
+	assert.Equal(t, "1.25.3", found["Nginx"].Version)
+	assert.Equal(t, "Web server", found["Nginx"].Category)
+
+	assert.Contains(t, found, "Cloudflare")
+	assert.Contains(t, found, "jQuery")
+	assert.Contains(t, found["Google Analytics"].Evidence, "cookie:_ga")
+	assert.Contains(t, found["Google Analytics"].Evidence, "js:gtag")
+
+	require.Contains(t, found, "PHP", "implied by WordPress")
+	assert.Equal(t, []string{"implied:WordPress"}, found["PHP"].Evidence)
+
+	assert.NotContains(t, found, "Drupal")
+}
+
+// TestDetectConfidenceTag tests partial confidence from tagged patterns
+func TestDetectConfidenceTag(t *testing.T) {
+	engine, err := Parse([]byte(`{"technologies": [
+		{"name": "Weak", "category": "Test", "html": ["weak-marker\\;confidence:40"]},
+		{"name": "Strong", "category": "Test", "html": ["strong-marker\\;confidence:60", "other-marker\\;confidence:60"]}
+	]}`))
+	require.NoError(t, err)
+
+	found := byName(engine.Detect(Input{HTML: "weak-marker strong-marker other-marker"}))
+	assert.Equal(t, 40, found["Weak"].Confidence)
+	assert.Equal(t, 100, found["Strong"].Confidence, "confidence is capped at 100")
+}
+
+// TestParseInvalidRules tests rule validation
+func TestParseInvalidRules(t *testing.T) {
+	_, err := Parse([]byte(`{"technologies": [{"name": "Broken", "html": ["("]}]}`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`{"technologies": [{"html": ["x"]}]}`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+// TestLoadFileOverridesEmbeddedRules tests overriding and extending rules from disk
+func TestLoadFileOverridesEmbeddedRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	err := os.WriteFile(path, []byte(`{"technologies": [
+		{"name": "Nginx", "category": "Reverse proxy", "headers": {"Server": "nginx"}},
+		{"name": "In-house CMS", "category": "CMS", "meta": {"generator": "^Acme CMS ([\\d.]+)\\;version:\\1"}}
+	]}`), 0o600)
+	require.NoError(t, err)
+
+	engine, err := LoadFile(path)
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set("Server", "nginx/1.25.3")
+	found := byName(engine.Detect(Input{
+		Headers: header,
+		Meta:    map[string][]string{"generator": {"Acme CMS 2.1"}},
+	}))
+
+	assert.Equal(t, "Reverse proxy", found["Nginx"].Category)
+	assert.Empty(t, found["Nginx"].Version, "override rule has no version tag")
+	assert.Equal(t, "2.1", found["In-house CMS"].Version)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
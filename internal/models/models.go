@@ -1,7 +1,16 @@
 package models
 
+import "time"
+
 type AnalysisRequest struct {
 	URL string `json:"url" example:"https://example.com"`
+	// TargetKeyword is an optional keyword or phrase to report on
+	TargetKeyword string `json:"targetKeyword,omitempty" example:"tomato seeds"`
+}
+
+// AnalysisOptions are the per-request settings of an analysis
+type AnalysisOptions struct {
+	TargetKeyword string
 }
 
 type HeadingCount struct {
@@ -17,14 +26,541 @@ type LinkAnalysis struct {
 	Internal     int `json:"internal" example:"5"`
 	External     int `json:"external" example:"3"`
 	Inaccessible int `json:"inaccessible" example:"1"`
+	// FragmentLinks counts the links whose fragment was checked against the
+	// anchors of their target page
+	FragmentLinks     int                `json:"fragmentLinks" example:"4"`
+	DanglingFragments []DanglingFragment `json:"danglingFragments"`
+	Markup            LinkMarkupReport   `json:"markup"`
+	// Categories counts links by scheme category: web, email, phone, ftp,
+	// data, javascript, file and app
+	Categories  map[string]int `json:"categories"`
+	SchemeLinks []SchemeLink   `json:"schemeLinks"`
+	// Relations counts web links by how their host relates to the page's:
+	// same-host, same-site (same registrable domain) or cross-site
+	Relations map[string]int `json:"relations"`
+}
+
+// SchemeLink is a link with a non-web scheme such as mailto:, tel: or ftp:
+type SchemeLink struct {
+	Href     string `json:"href" example:"tel:+44 20 7946 0958"`
+	Scheme   string `json:"scheme" example:"tel"`
+	Category string `json:"category" example:"phone"`
+	Valid    bool   `json:"valid" example:"true"`
+	// Accessible is set only for FTP links, when FTP checking is enabled
+	Accessible *bool    `json:"accessible,omitempty" example:"true"`
+	Error      string   `json:"error,omitempty"`
+	Issues     []string `json:"issues"`
+}
+
+// DanglingFragment is a link to a fragment its target page has no anchor for
+type DanglingFragment struct {
+	Href     string `json:"href" example:"/docs#install"`
+	URL      string `json:"url" example:"https://example.com/docs#install"`
+	Fragment string `json:"fragment" example:"install"`
+	SamePage bool   `json:"samePage" example:"false"`
+}
+
+// AnchorText is the text of a link
+type AnchorText struct {
+	Href string `json:"href" example:"/pricing"`
+	Text string `json:"text" example:"Click here"`
+}
+
+// DuplicateLink is a destination linked more than once, with the distinct
+// texts used for it
+type DuplicateLink struct {
+	URL   string   `json:"url" example:"https://example.com/pricing"`
+	Count int      `json:"count" example:"3"`
+	Texts []string `json:"texts" example:"Pricing,See plans"`
+}
+
+// LinkMarkupReport audits how links are marked up. Counts holds the number
+// of links per finding.
+type LinkMarkupReport struct {
+	Counts            map[string]int  `json:"counts"`
+	Nofollow          []string        `json:"nofollow" example:"https://partner.example.net/"`
+	Sponsored         []string        `json:"sponsored"`
+	UGC               []string        `json:"ugc"`
+	UnsafeTargetBlank []string        `json:"unsafeTargetBlank" example:"https://external.example.org/"`
+	EmptyAnchors      []string        `json:"emptyAnchors"`
+	ImagesWithoutAlt  []string        `json:"imagesWithoutAlt" example:"/"`
+	GenericText       []AnchorText    `json:"genericText"`
+	Duplicates        []DuplicateLink `json:"duplicates"`
+}
+
+// LoginFormDetection is the scored result of looking for a login UI on the page
+type LoginFormDetection struct {
+	Detected   bool     `json:"detected" example:"true"`
+	Confidence float64  `json:"confidence" example:"0.9"`
+	Evidence   []string `json:"evidence" example:"password-input,button-text"`
+}
+
+// SSOProvider is a third-party identity provider offered for sign-in
+type SSOProvider struct {
+	Name     string   `json:"name" example:"Google"`
+	Protocol string   `json:"protocol,omitempty" example:"oidc"`
+	Evidence []string `json:"evidence" example:"link:https://accounts.google.com/o/oauth2/v2/auth"`
+}
+
+// SSODetection lists the single sign-on options found on the page
+type SSODetection struct {
+	Detected  bool          `json:"detected" example:"true"`
+	Providers []SSOProvider `json:"providers"`
+}
+
+// Resource is a subresource referenced by the page
+type Resource struct {
+	URL        string `json:"url" example:"https://cdn.example.com/app.js"`
+	Type       string `json:"type" example:"script"`
+	Element    string `json:"element" example:"script"`
+	Attribute  string `json:"attribute" example:"src"`
+	Host       string `json:"host" example:"cdn.example.com"`
+	ThirdParty bool   `json:"thirdParty" example:"false"`
+	Available  *bool  `json:"available,omitempty" example:"true"`
+	StatusCode int    `json:"statusCode,omitempty" example:"200"`
+	Size       int64  `json:"size,omitempty" example:"48213"`
+}
+
+// ResourceGroup aggregates resources of one type
+type ResourceGroup struct {
+	Count        int   `json:"count" example:"4"`
+	FirstParty   int   `json:"firstParty" example:"3"`
+	ThirdParty   int   `json:"thirdParty" example:"1"`
+	Inaccessible int   `json:"inaccessible" example:"0"`
+	Bytes        int64 `json:"bytes" example:"120431"`
+}
+
+// ResourceInventory lists every subresource grouped by type and origin.
+// PageWeight is the HTML size plus every subresource size that could be determined.
+// Broken subresources are counted here, separately from inaccessible links.
+type ResourceInventory struct {
+	Total              int                      `json:"total" example:"12"`
+	FirstParty         int                      `json:"firstParty" example:"9"`
+	ThirdParty         int                      `json:"thirdParty" example:"3"`
+	ThirdPartyHosts    []string                 `json:"thirdPartyHosts" example:"fonts.googleapis.com"`
+	ByType             map[string]ResourceGroup `json:"byType"`
+	Inaccessible       int                      `json:"inaccessible" example:"1"`
+	InaccessibleByType map[string][]string      `json:"inaccessibleByType"`
+	PageWeight         int64                    `json:"pageWeight" example:"512000"`
+	Resources          []Resource               `json:"resources"`
+}
+
+// MixedContentItem is an insecure http:// reference on an HTTPS page
+type MixedContentItem struct {
+	URL       string `json:"url" example:"http://cdn.example.com/app.js"`
+	Element   string `json:"element" example:"script"`
+	Attribute string `json:"attribute" example:"src"`
+	Category  string `json:"category" example:"active"`
+	Blocked   bool   `json:"blocked" example:"true"`
+}
+
+// MixedContentReport separates content browsers block (active) from content
+// they only warn about (passive media and insecure form targets)
+type MixedContentReport struct {
+	Applicable    bool               `json:"applicable" example:"true"`
+	Active        int                `json:"active" example:"1"`
+	Passive       int                `json:"passive" example:"2"`
+	InsecureForms int                `json:"insecureForms" example:"0"`
+	Items         []MixedContentItem `json:"items"`
+}
+
+// CheckResult is the verdict of a graded check. Status is pass, warn or fail.
+type CheckResult struct {
+	Status string   `json:"status" example:"pass"`
+	Issues []string `json:"issues"`
+}
+
+// HeaderCheck is the verdict for one security header
+type HeaderCheck struct {
+	Name    string `json:"name" example:"X-Content-Type-Options"`
+	Present bool   `json:"present" example:"true"`
+	Value   string `json:"value,omitempty" example:"nosniff"`
+	CheckResult
+}
+
+// CSPReport is a parsed Content-Security-Policy
+type CSPReport struct {
+	ReportOnly bool                `json:"reportOnly" example:"false"`
+	Directives map[string][]string `json:"directives"`
+	Weaknesses []string            `json:"weaknesses" example:"'unsafe-inline' allowed in script-src"`
+}
+
+// HSTSReport is a parsed Strict-Transport-Security header
+type HSTSReport struct {
+	MaxAge            int64 `json:"maxAge" example:"31536000"`
+	IncludeSubDomains bool  `json:"includeSubDomains" example:"true"`
+	Preload           bool  `json:"preload" example:"false"`
+}
+
+// SecurityHeadersReport grades the security headers of the page response
+type SecurityHeadersReport struct {
+	Score  int           `json:"score" example:"75"`
+	Grade  string        `json:"grade" example:"B"`
+	Checks []HeaderCheck `json:"checks"`
+	CSP    *CSPReport    `json:"csp,omitempty"`
+	HSTS   *HSTSReport   `json:"hsts,omitempty"`
+}
+
+// CertificateInfo describes one certificate presented by the server
+type CertificateInfo struct {
+	Subject   string    `json:"subject" example:"CN=example.com"`
+	Issuer    string    `json:"issuer" example:"CN=R3,O=Let's Encrypt,C=US"`
+	DNSNames  []string  `json:"dnsNames,omitempty" example:"example.com,www.example.com"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// TLSReport describes the TLS connection and certificate of an HTTPS page.
+// Chain runs from the leaf certificate up to the root.
+type TLSReport struct {
+	Version           string            `json:"version" example:"TLS 1.3"`
+	CipherSuite       string            `json:"cipherSuite" example:"TLS_AES_128_GCM_SHA256"`
+	ALPN              string            `json:"alpn,omitempty" example:"h2"`
+	Certificate       CertificateInfo   `json:"certificate"`
+	Chain             []CertificateInfo `json:"chain"`
+	DaysRemaining     int               `json:"daysRemaining" example:"62"`
+	ExpiresSoon       bool              `json:"expiresSoon" example:"false"`
+	ExpiryWindowDays  int               `json:"expiryWindowDays" example:"30"`
+	HostnameMatch     bool              `json:"hostnameMatch" example:"true"`
+	ChainValid        bool              `json:"chainValid" example:"true"`
+	VerificationError string            `json:"verificationError,omitempty"`
+}
+
+// CookieInfo describes a cookie set by the page response or a redirect leading
+// to it. The cookie value is never reported.
+type CookieInfo struct {
+	Name       string     `json:"name" example:"sessionid"`
+	Domain     string     `json:"domain" example:"example.com"`
+	Path       string     `json:"path,omitempty" example:"/"`
+	Secure     bool       `json:"secure" example:"true"`
+	HttpOnly   bool       `json:"httpOnly" example:"true"`
+	SameSite   string     `json:"sameSite,omitempty" example:"Lax"`
+	Expires    *time.Time `json:"expires,omitempty"`
+	MaxAge     int        `json:"maxAge,omitempty" example:"3600"`
+	Session    bool       `json:"session" example:"true"`
+	SetBy      string     `json:"setBy" example:"example.com"`
+	ThirdParty bool       `json:"thirdParty" example:"false"`
+}
+
+// CookieFinding is a problem found with one cookie
+type CookieFinding struct {
+	Cookie string `json:"cookie" example:"sessionid"`
+	Issue  string `json:"issue" example:"session cookie without HttpOnly"`
+}
+
+// CookieReport summarizes the cookies set while loading the page
+type CookieReport struct {
+	Total             int             `json:"total" example:"3"`
+	ThirdPartyDomains []string        `json:"thirdPartyDomains" example:"tracker.example.net"`
+	Cookies           []CookieInfo    `json:"cookies"`
+	Findings          []CookieFinding `json:"findings"`
+}
+
+// Technology is a product detected on the page by the fingerprinting rules
+type Technology struct {
+	Name       string   `json:"name" example:"WordPress"`
+	Category   string   `json:"category" example:"CMS"`
+	Version    string   `json:"version,omitempty" example:"6.4.2"`
+	Confidence int      `json:"confidence" example:"100"`
+	Evidence   []string `json:"evidence" example:"meta:generator"`
+}
+
+// Tracker is a third-party analytics, advertising or session replay tag
+type Tracker struct {
+	Vendor      string   `json:"vendor" example:"Google Analytics"`
+	Category    string   `json:"category" example:"analytics"`
+	PropertyIDs []string `json:"propertyIds" example:"G-ABC123XYZ"`
+	Sources     []string `json:"sources" example:"script:https://www.googletagmanager.com/gtag/js?id=G-ABC123XYZ"`
+}
+
+// TrackerReport is the per-page inventory of trackers and the third-party
+// domains contacted by subresources
+type TrackerReport struct {
+	Count             int            `json:"count" example:"2"`
+	ByCategory        map[string]int `json:"byCategory"`
+	Trackers          []Tracker      `json:"trackers"`
+	ThirdPartyDomains []string       `json:"thirdPartyDomains" example:"www.googletagmanager.com"`
+}
+
+// ConsentPlatform is a consent management platform found on the page
+type ConsentPlatform struct {
+	Name     string   `json:"name" example:"OneTrust"`
+	Evidence []string `json:"evidence" example:"script:https://cdn.cookielaw.org/scripttemplates/otSDKStub.js"`
+}
+
+// ConsentReport describes the cookie consent banner, if any, and flags
+// trackers loaded without one
+type ConsentReport struct {
+	Detected               bool              `json:"detected" example:"true"`
+	Platforms              []ConsentPlatform `json:"platforms"`
+	CustomBanner           bool              `json:"customBanner" example:"false"`
+	BannerEvidence         []string          `json:"bannerEvidence"`
+	TrackersWithoutConsent bool              `json:"trackersWithoutConsent" example:"false"`
+	UnconsentedTrackers    []string          `json:"unconsentedTrackers"`
+}
+
+// SecretFinding is a credential or piece of sensitive data found in the page
+// source. Match is always redacted.
+type SecretFinding struct {
+	Rule        string `json:"rule" example:"aws-access-key-id"`
+	Description string `json:"description" example:"AWS access key ID"`
+	Severity    string `json:"severity" example:"high"`
+	Match       string `json:"match" example:"AKIA****************"`
+	Location    string `json:"location" example:"script"`
+	Line        int    `json:"line" example:"42"`
+	Column      int    `json:"column" example:"17"`
+}
+
+// SecretsReport lists the sensitive data exposed in text, comments and inline scripts
+type SecretsReport struct {
+	Total      int             `json:"total" example:"1"`
+	BySeverity map[string]int  `json:"bySeverity"`
+	Findings   []SecretFinding `json:"findings"`
+}
+
+// IntegrityCheck is the Subresource Integrity audit of one external script or stylesheet
+type IntegrityCheck struct {
+	URL              string   `json:"url" example:"https://cdn.example.net/lib.min.js"`
+	Element          string   `json:"element" example:"script"`
+	Host             string   `json:"host" example:"cdn.example.net"`
+	ThirdParty       bool     `json:"thirdParty" example:"true"`
+	Integrity        string   `json:"integrity,omitempty" example:"sha384-oqVuAfXRKap7fdgcCY5uykM6+R9GqQ8K/uxy9rx7HNQlGYl1kPzQho1wx4JwY8wC"`
+	HasIntegrity     bool     `json:"hasIntegrity" example:"true"`
+	ValidFormat      bool     `json:"validFormat" example:"true"`
+	Algorithms       []string `json:"algorithms" example:"sha384"`
+	CrossOrigin      string   `json:"crossOrigin,omitempty" example:"anonymous"`
+	CrossOriginValid bool     `json:"crossOriginValid" example:"true"`
+	// Verified is set only when hash verification is enabled
+	Verified          *bool    `json:"verified,omitempty" example:"true"`
+	VerificationError string   `json:"verificationError,omitempty"`
+	Issues            []string `json:"issues"`
+}
+
+// IntegrityReport summarizes Subresource Integrity use across external
+// scripts and stylesheets
+type IntegrityReport struct {
+	Total                 int              `json:"total" example:"3"`
+	WithIntegrity         int              `json:"withIntegrity" example:"1"`
+	WithoutIntegrity      int              `json:"withoutIntegrity" example:"2"`
+	UnprotectedThirdParty []string         `json:"unprotectedThirdParty" example:"https://cdn.example.net/widget.js"`
+	Checks                []IntegrityCheck `json:"checks"`
+}
+
+// DoctypeInfo describes the document type declaration and the rendering mode
+// a browser selects for it
+type DoctypeInfo struct {
+	Present       bool   `json:"present" example:"true"`
+	Name          string `json:"name,omitempty" example:"html"`
+	PublicID      string `json:"publicId,omitempty" example:"-//W3C//DTD HTML 4.01 Transitional//EN"`
+	SystemID      string `json:"systemId,omitempty" example:"http://www.w3.org/TR/html4/loose.dtd"`
+	Version       string `json:"version" example:"HTML 4.01"`
+	Variant       string `json:"variant,omitempty" example:"Transitional"`
+	Known         bool   `json:"known" example:"true"`
+	RenderingMode string `json:"renderingMode" example:"almost-standards"`
+	ContentType   string `json:"contentType,omitempty" example:"text/html"`
+	ServedAsXHTML bool   `json:"servedAsXhtml" example:"false"`
+}
+
+// ConformanceFinding is a markup problem found while tokenizing the page
+type ConformanceFinding struct {
+	Rule    string `json:"rule" example:"duplicate-id"`
+	Message string `json:"message" example:"id \"main\" is already used at line 12"`
+	Element string `json:"element" example:"div"`
+	Line    int    `json:"line" example:"48"`
+	Column  int    `json:"column" example:"5"`
+}
+
+// ConformanceReport lists the markup quality findings of the page. Findings
+// beyond the reporting limit are counted but not listed.
+type ConformanceReport struct {
+	Total     int                  `json:"total" example:"3"`
+	ByRule    map[string]int       `json:"byRule"`
+	Truncated bool                 `json:"truncated" example:"false"`
+	Findings  []ConformanceFinding `json:"findings"`
+}
+
+// SEOCheck is the verdict for one SEO rule
+type SEOCheck struct {
+	Name   string `json:"name" example:"meta-description"`
+	Weight int    `json:"weight" example:"15"`
+	Value  string `json:"value,omitempty" example:"Short description"`
+	CheckResult
+}
+
+// SEOReport is the weighted SEO audit of the page
+type SEOReport struct {
+	Score  int        `json:"score" example:"82"`
+	Grade  string     `json:"grade" example:"B"`
+	Checks []SEOCheck `json:"checks"`
+}
+
+// CanonicalCheck is the validation of a canonical URL declared by the page.
+// Source is html for a <link> element and header for an HTTP Link header.
+type CanonicalCheck struct {
+	URL             string   `json:"url" example:"https://www.example.com/products"`
+	Source          string   `json:"source" example:"html"`
+	StatusCode      int      `json:"statusCode,omitempty" example:"200"`
+	RedirectsTo     string   `json:"redirectsTo,omitempty" example:"https://www.example.com/products/"`
+	CrossDomain     bool     `json:"crossDomain" example:"false"`
+	SelfReferencing bool     `json:"selfReferencing" example:"true"`
+	Error           string   `json:"error,omitempty"`
+	Issues          []string `json:"issues"`
+}
+
+// HreflangAlternate is the validation of one language alternate of the page
+type HreflangAlternate struct {
+	Hreflang   string `json:"hreflang" example:"de-CH"`
+	URL        string `json:"url" example:"https://www.example.com/de-ch/products"`
+	Source     string `json:"source" example:"html"`
+	ValidCode  bool   `json:"validCode" example:"true"`
+	StatusCode int    `json:"statusCode,omitempty" example:"200"`
+	// Reciprocal is set when the alternate was fetched and tells whether it
+	// links back to the page
+	Reciprocal *bool    `json:"reciprocal,omitempty" example:"true"`
+	Error      string   `json:"error,omitempty"`
+	Issues     []string `json:"issues"`
+}
+
+// CanonicalReport validates the canonical URL and hreflang alternates of the
+// page, from both the markup and the HTTP Link header
+type CanonicalReport struct {
+	Canonicals  []CanonicalCheck    `json:"canonicals"`
+	Alternates  []HreflangAlternate `json:"alternates"`
+	HasXDefault bool                `json:"hasXDefault" example:"true"`
+	Issues      []string            `json:"issues"`
+}
+
+// ReadabilityScore is the result of one readability formula. Interpretation
+// is set for reading ease scores; grade levels are read as US school grades.
+type ReadabilityScore struct {
+	Name           string  `json:"name" example:"flesch-reading-ease"`
+	Score          float64 `json:"score" example:"64.2"`
+	Interpretation string  `json:"interpretation,omitempty" example:"standard"`
+}
+
+// ContentReport describes the main content of the page, with navigation,
+// sidebars and other boilerplate removed
+type ContentReport struct {
+	// Element is the element the main content was taken from
+	Element            string             `json:"element" example:"article"`
+	Text               string             `json:"text"`
+	WordCount          int                `json:"wordCount" example:"812"`
+	SentenceCount      int                `json:"sentenceCount" example:"47"`
+	TextToHTMLRatio    float64            `json:"textToHtmlRatio" example:"18.25"`
+	ReadingTimeMinutes int                `json:"readingTimeMinutes" example:"4"`
+	Language           string             `json:"language,omitempty" example:"en"`
+	LanguageConfidence float64            `json:"languageConfidence" example:"0.82"`
+	DeclaredLanguage   string             `json:"declaredLanguage,omitempty" example:"en-US"`
+	Readability        []ReadabilityScore `json:"readability"`
+}
+
+// Keyword is a word or n-gram of the main content with where else it appears
+type Keyword struct {
+	Term              string  `json:"term" example:"tomato"`
+	Count             int     `json:"count" example:"12"`
+	Density           float64 `json:"density" example:"1.48"`
+	InTitle           bool    `json:"inTitle" example:"true"`
+	InH1              bool    `json:"inH1" example:"true"`
+	InMetaDescription bool    `json:"inMetaDescription" example:"false"`
+	InURL             bool    `json:"inUrl" example:"true"`
+}
+
+// TargetKeywordReport tells where and how often the requested keyword
+// appears. Occurrences are counted per location: title, meta-description,
+// h1, headings, url, content, first-100-words, image-alt and anchor-text.
+type TargetKeywordReport struct {
+	Keyword     string         `json:"keyword" example:"tomato seeds"`
+	Count       int            `json:"count" example:"4"`
+	Density     float64        `json:"density" example:"0.49"`
+	Occurrences map[string]int `json:"occurrences"`
+	// Missing lists the prominent locations the keyword is absent from
+	Missing []string `json:"missing" example:"meta-description"`
+}
+
+// KeywordReport lists the most frequent keywords and phrases of the main
+// content, stop words excluded
+type KeywordReport struct {
+	Language   string               `json:"language,omitempty" example:"en"`
+	TotalWords int                  `json:"totalWords" example:"812"`
+	Keywords   []Keyword            `json:"keywords"`
+	Bigrams    []Keyword            `json:"bigrams"`
+	Trigrams   []Keyword            `json:"trigrams"`
+	Target     *TargetKeywordReport `json:"target,omitempty"`
+}
+
+// ViewportReport describes the viewport meta tag of the page
+type ViewportReport struct {
+	Present      bool     `json:"present" example:"true"`
+	Content      string   `json:"content,omitempty" example:"width=device-width, initial-scale=1"`
+	Width        string   `json:"width,omitempty" example:"device-width"`
+	InitialScale string   `json:"initialScale,omitempty" example:"1"`
+	ZoomDisabled bool     `json:"zoomDisabled" example:"false"`
+	Issues       []string `json:"issues"`
+}
+
+// ResponsiveImageReport counts the images that adapt to the screen
+type ResponsiveImageReport struct {
+	Total      int `json:"total" example:"12"`
+	WithSrcset int `json:"withSrcset" example:"8"`
+	WithSizes  int `json:"withSizes" example:"6"`
+	InPicture  int `json:"inPicture" example:"2"`
+	// SrcsetWithoutSizes lists images with width descriptors but no sizes,
+	// which browsers treat as full viewport width
+	SrcsetWithoutSizes []string `json:"srcsetWithoutSizes" example:"/img/hero-800.jpg"`
+}
+
+// WideElement is an element with a fixed width wider than a phone screen.
+// Source is attribute for a width attribute and style for an inline style.
+type WideElement struct {
+	Element string `json:"element" example:"table"`
+	Width   int    `json:"width" example:"960"`
+	Source  string `json:"source" example:"attribute"`
+}
+
+// TapTargetPair is two links or controls placed next to each other inline,
+// which are hard to tap apart on a touch screen
+type TapTargetPair struct {
+	First  string `json:"first" example:"a \"Terms\""`
+	Second string `json:"second" example:"a \"Privacy\""`
+}
+
+// MobileReport describes how ready the page is for mobile devices
+type MobileReport struct {
+	MobileFriendly     bool                  `json:"mobileFriendly" example:"true"`
+	Viewport           ViewportReport        `json:"viewport"`
+	ResponsiveImages   ResponsiveImageReport `json:"responsiveImages"`
+	WideElements       []WideElement         `json:"wideElements"`
+	AdjacentTapTargets []TapTargetPair       `json:"adjacentTapTargets"`
+	AppleTouchIcon     string                `json:"appleTouchIcon,omitempty" example:"/apple-touch-icon.png"`
+	ThemeColor         string                `json:"themeColor,omitempty" example:"#0a84ff"`
+	Issues             []string              `json:"issues"`
 }
 
 type AnalysisResponse struct {
-	HTMLVersion       string       `json:"htmlVersion" example:"HTML5"`
-	Title             string       `json:"title" example:"Example Domain"`
-	Headings          HeadingCount `json:"headings"`
-	Links             LinkAnalysis `json:"links"`
-	ContainsLoginForm bool         `json:"containsLoginForm" example:"false"`
+	HTMLVersion       string                `json:"htmlVersion" example:"HTML5"`
+	Doctype           DoctypeInfo           `json:"doctype"`
+	Title             string                `json:"title" example:"Example Domain"`
+	Headings          HeadingCount          `json:"headings"`
+	Links             LinkAnalysis          `json:"links"`
+	ContainsLoginForm bool                  `json:"containsLoginForm" example:"false"`
+	LoginForm         LoginFormDetection    `json:"loginForm"`
+	SSO               SSODetection          `json:"sso"`
+	Resources         ResourceInventory     `json:"resources"`
+	MixedContent      MixedContentReport    `json:"mixedContent"`
+	SecurityHeaders   SecurityHeadersReport `json:"securityHeaders"`
+	TLS               *TLSReport            `json:"tls,omitempty"`
+	Cookies           CookieReport          `json:"cookies"`
+	Technologies      []Technology          `json:"technologies"`
+	Trackers          TrackerReport         `json:"trackers"`
+	Consent           ConsentReport         `json:"consent"`
+	Secrets           SecretsReport         `json:"secrets"`
+	Integrity         IntegrityReport       `json:"integrity"`
+	Conformance       ConformanceReport     `json:"conformance"`
+	SEO               SEOReport             `json:"seo"`
+	Canonical         CanonicalReport       `json:"canonical"`
+	Content           ContentReport         `json:"content"`
+	Keywords          KeywordReport         `json:"keywords"`
+	Mobile            MobileReport          `json:"mobile"`
 }
 
 type ErrorResponse struct {
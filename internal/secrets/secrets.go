@@ -0,0 +1,199 @@
+// Package secrets finds credentials and other sensitive data in page source
+// using a pluggable set of rules.
+//
+// A rule is a regular expression whose first capture group, or the whole
+// match when there is none, is the sensitive value. A rule may also require
+// a minimum Shannon entropy so that placeholders such as "your-api-key-here"
+// are not reported. Matches are always redacted before they leave the package.
+package secrets
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/maheshjq/web-analyzer_v1/internal/models"
+)
+
+//go:embed rules.json
+var embeddedRules []byte
+
+// Rule is a secret definition as stored in the rules file
+type Rule struct {
+	ID          string  `json:"id"`
+	Description string  `json:"description"`
+	Severity    string  `json:"severity"`
+	Pattern     string  `json:"pattern"`
+	MinEntropy  float64 `json:"minEntropy,omitempty"`
+}
+
+type ruleFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+type rule struct {
+	Rule
+	re *regexp.Regexp
+}
+
+// Scanner matches text against a compiled rule set
+type Scanner struct {
+	rules []*rule
+}
+
+var (
+	defaultScanner    *Scanner
+	defaultScannerErr error
+	defaultOnce       sync.Once
+)
+
+// Default returns the scanner built from the embedded rules
+func Default() *Scanner {
+	defaultOnce.Do(func() {
+		defaultScanner, defaultScannerErr = Parse(embeddedRules)
+	})
+	if defaultScannerErr != nil {
+		panic(fmt.Sprintf("invalid embedded secret rules: %v", defaultScannerErr))
+	}
+	return defaultScanner
+}
+
+// LoadFile builds a scanner from the embedded rules overridden by the rules
+// in the given file. A rule in the file replaces the embedded rule with the
+// same ID; rules with new IDs are added. A rule with an empty pattern
+// disables the embedded rule it replaces.
+func LoadFile(path string) (*Scanner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret rules: %w", err)
+	}
+
+	var base, override ruleFile
+	if err := json.Unmarshal(embeddedRules, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded secret rules: %w", err)
+	}
+	if err := json.Unmarshal(data, &override); err != nil {
+		return nil, fmt.Errorf("failed to parse secret rules %s: %w", path, err)
+	}
+
+	index := make(map[string]int)
+	for i, r := range base.Rules {
+		index[r.ID] = i
+	}
+	for _, r := range override.Rules {
+		if i, ok := index[r.ID]; ok {
+			base.Rules[i] = r
+		} else {
+			index[r.ID] = len(base.Rules)
+			base.Rules = append(base.Rules, r)
+		}
+	}
+
+	return New(base.Rules)
+}
+
+// Parse builds a scanner from a JSON rules document
+func Parse(data []byte) (*Scanner, error) {
+	var file ruleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse secret rules: %w", err)
+	}
+	return New(file.Rules)
+}
+
+// New builds a scanner from rules
+func New(rules []Rule) (*Scanner, error) {
+	scanner := &Scanner{}
+	for _, r := range rules {
+		if r.ID == "" {
+			return nil, fmt.Errorf("secret rule without an id")
+		}
+		if r.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern: %w", r.ID, err)
+		}
+		if r.Severity == "" {
+			r.Severity = "medium"
+		}
+		scanner.rules = append(scanner.rules, &rule{Rule: r, re: re})
+	}
+	return scanner, nil
+}
+
+// Scan returns the redacted secrets found in text. location names the part
+// of the page the text came from; line and column give the position of the
+// first character of text and are used to position each finding.
+func (s *Scanner) Scan(text, location string, line, column int) []models.SecretFinding {
+	var findings []models.SecretFinding
+	for _, r := range s.rules {
+		for _, match := range r.re.FindAllStringSubmatchIndex(text, -1) {
+			start, end := match[0], match[1]
+			if len(match) >= 4 && match[2] >= 0 {
+				start, end = match[2], match[3]
+			}
+			value := text[start:end]
+			if r.MinEntropy > 0 && Entropy(value) < r.MinEntropy {
+				continue
+			}
+
+			findingLine, findingColumn := line, column
+			before := text[:start]
+			if newlines := strings.Count(before, "\n"); newlines > 0 {
+				findingLine += newlines
+				findingColumn = 1
+				before = before[strings.LastIndex(before, "\n")+1:]
+			}
+			findingColumn += utf8.RuneCountInString(before)
+
+			findings = append(findings, models.SecretFinding{
+				Rule:        r.ID,
+				Description: r.Description,
+				Severity:    r.Severity,
+				Match:       Redact(value),
+				Location:    location,
+				Line:        findingLine,
+				Column:      findingColumn,
+			})
+		}
+	}
+	return findings
+}
+
+// redactionMask replaces the hidden part of every redacted value. Its width
+// is fixed so that it does not reveal the length of the value.
+const redactionMask = "****************"
+
+// Redact keeps just enough of a value to recognise it: a prefix of at most
+// four characters and a quarter of the value, followed by redactionMask
+func Redact(value string) string {
+	runes := []rune(value)
+	return string(runes[:min(len(runes)/4, 4)]) + redactionMask
+}
+
+// Entropy is the Shannon entropy of s in bits per character
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
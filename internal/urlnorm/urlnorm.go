@@ -0,0 +1,265 @@
+// Package urlnorm reduces URLs that address the same resource to a single
+// form, for use as cache keys and to de-duplicate links.
+//
+// Normalization lowercases the scheme and host, converts internationalized
+// host names to punycode, drops default ports, resolves dot segments,
+// normalizes percent-encoding, sorts the query parameters and removes
+// tracking parameters and the fragment. Whether a trailing slash is kept,
+// added or removed is a policy of the Normalizer.
+package urlnorm
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// TrailingSlash is the policy for a slash at the end of a non-root path
+type TrailingSlash int
+
+const (
+	// KeepTrailingSlash leaves paths as they are; /docs and /docs/ differ
+	KeepTrailingSlash TrailingSlash = iota
+	// AddTrailingSlash turns /docs into /docs/, except for file names such
+	// as /report.pdf
+	AddTrailingSlash
+	// RemoveTrailingSlash turns /docs/ into /docs
+	RemoveTrailingSlash
+)
+
+// DefaultTrackingParams are the query parameters added by analytics and
+// advertising platforms. A name ending in "*" matches as a prefix.
+var DefaultTrackingParams = []string{
+	"utm_*", "gclid", "gclsrc", "dclid", "gbraid", "wbraid", "fbclid", "msclkid",
+	"yclid", "twclid", "ttclid", "li_fat_id", "igshid", "mc_cid", "mc_eid",
+	"_ga", "_gl", "_hsenc", "_hsmi", "mkt_tok", "vero_id", "oly_anon_id",
+	"oly_enc_id", "s_cid", "ref_src",
+}
+
+// defaultPorts are dropped from the host
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ftp":   "21",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// Normalizer holds the normalization policies. The zero value keeps
+// trailing slashes and every query parameter, and drops the fragment.
+type Normalizer struct {
+	TrailingSlash TrailingSlash
+	// KeepFragment keeps the fragment, for links to a section of a page
+	KeepFragment bool
+	// TrackingParams are removed from the query, case-insensitively
+	TrackingParams []string
+}
+
+// Default returns the normalizer that keeps trailing slashes and removes
+// DefaultTrackingParams
+func Default() *Normalizer {
+	return &Normalizer{TrackingParams: DefaultTrackingParams}
+}
+
+// Normalize parses and normalizes a URL with the default normalizer
+func Normalize(raw string) (string, error) {
+	return Default().Normalize(raw)
+}
+
+// Normalize parses and normalizes a URL
+func (n *Normalizer) Normalize(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	return n.NormalizeURL(u).String(), nil
+}
+
+// NormalizeURL returns a normalized copy of u
+func (n *Normalizer) NormalizeURL(u *url.URL) *url.URL {
+	c := *u
+	c.Scheme = strings.ToLower(c.Scheme)
+	if c.User != nil {
+		user := *c.User
+		c.User = &user
+	}
+
+	if c.Host != "" {
+		host := strings.TrimSuffix(strings.ToLower(c.Hostname()), ".")
+		if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+			host = ascii
+		}
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		if port := c.Port(); port != "" && port != defaultPorts[c.Scheme] {
+			host += ":" + port
+		}
+		c.Host = host
+	}
+
+	if c.Opaque == "" {
+		path := normalizeEscapes(c.EscapedPath())
+		path = removeDotSegments(path)
+		if path == "" && c.Host != "" {
+			path = "/"
+		}
+		path = n.applyTrailingSlash(path)
+		c.Path, _ = url.PathUnescape(path)
+		c.RawPath = path
+	}
+
+	c.RawQuery = n.normalizeQuery(c.RawQuery)
+	c.ForceQuery = false
+
+	if n.KeepFragment {
+		fragment := normalizeEscapes(c.EscapedFragment())
+		c.Fragment, _ = url.PathUnescape(fragment)
+		c.RawFragment = fragment
+	} else {
+		c.Fragment = ""
+		c.RawFragment = ""
+	}
+	return &c
+}
+
+func (n *Normalizer) applyTrailingSlash(path string) string {
+	if path == "" || path == "/" {
+		return path
+	}
+	switch n.TrailingSlash {
+	case AddTrailingSlash:
+		last := path[strings.LastIndex(path, "/")+1:]
+		if last != "" && !strings.Contains(last, ".") {
+			return path + "/"
+		}
+	case RemoveTrailingSlash:
+		return strings.TrimRight(path, "/")
+	}
+	return path
+}
+
+// normalizeQuery drops tracking parameters and empty segments, as in a&&b,
+// and sorts the rest by name, keeping the order of repeated names. Parameters
+// without a value, such as a=, are kept since servers may tell them apart.
+func (n *Normalizer) normalizeQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	type param struct {
+		name string
+		raw  string
+	}
+	var params []param
+	for _, raw := range strings.Split(rawQuery, "&") {
+		if raw == "" {
+			continue
+		}
+		raw = normalizeEscapes(raw)
+		name, _, _ := strings.Cut(raw, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil {
+			name = decoded
+		}
+		if n.isTrackingParam(name) {
+			continue
+		}
+		params = append(params, param{name, raw})
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return params[i].name < params[j].name
+	})
+
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = p.raw
+	}
+	return strings.Join(parts, "&")
+}
+
+func (n *Normalizer) isTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, tracking := range n.TrackingParams {
+		tracking = strings.ToLower(tracking)
+		if prefix, ok := strings.CutSuffix(tracking, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == tracking {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeEscapes uppercases the hex digits of percent-escapes and decodes
+// those of unreserved characters (RFC 3986, section 6.2.2.2)
+func normalizeEscapes(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
+		}
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if isUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			b.WriteString(strings.ToUpper(s[i : i+3]))
+		}
+		i += 2
+	}
+	return b.String()
+}
+
+// removeDotSegments resolves "." and ".." segments (RFC 3986, section 5.2.4)
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+	segments := strings.Split(path, "/")
+	var out []string
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch segment {
+		case ".":
+			if last {
+				out = append(out, "")
+			}
+		case "..":
+			if len(out) > 1 || (len(out) == 1 && out[0] != "") {
+				out = out[:len(out)-1]
+			}
+			if last {
+				out = append(out, "")
+			}
+		default:
+			out = append(out, segment)
+		}
+	}
+	return strings.Join(out, "/")
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}